@@ -0,0 +1,319 @@
+package openapi
+
+import (
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bundle pulls all the objects referenced from other documents into
+// the components object of the document, and rewrites the references
+// to point them, so that the document becomes a single self-contained
+// document. The component names are taken from the references and
+// suffixed with a number if they collide with existing ones.
+// Path item objects are inlined in OAS 3.0, because components object
+// has no place for them.
+// The objects pulled from other documents are copied, so the objects
+// cached in the Loader and shared with other documents are not changed.
+// If some references cannot be resolved, they are kept as they are and
+// returned as ErrorList, with the location of each reference.
+func (doc *Document) Bundle() error {
+	if doc.Components == nil {
+		doc.Components = &Components{}
+	}
+	b := &bundler{
+		doc:   doc,
+		v:     &validator{},
+		names: map[string]string{},
+		c:     &copier{doc: doc},
+	}
+	b.w = &refWalker{onRef: b.onRef}
+	b.w.document(doc)
+	doc.locate(b.v.errs)
+	return b.v.err()
+}
+
+type bundler struct {
+	doc *Document
+	w   *refWalker
+	v   *validator
+	// names holds the component names keyed by absolute reference.
+	names map[string]string
+	c     *copier
+}
+
+func (b *bundler) onRef(ptr, ref string, obj interface{}) interface{} {
+	if strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	abs, local := b.absolute(ref)
+	if local != "" {
+		setRef(obj, local)
+		return nil
+	}
+	if abs == nil {
+		b.v.report(ptr, ErrUnresolvedRef{Ref: ref, Err: ErrFormatInvalid{Target: "reference", Format: "URI"}})
+		return nil
+	}
+	key := abs.String()
+	kind, ok := b.componentKind(obj)
+	if name, done := b.names[key]; done && ok {
+		setRef(obj, "#/components/"+kind+"/"+name)
+		return nil
+	}
+	resolved, err := resolve(b.doc, ref, obj)
+	if err == nil && reflect.TypeOf(resolved) != reflect.TypeOf(obj) {
+		err = ErrTypeAssertion
+	}
+	if err != nil {
+		b.v.report(ptr, ErrUnresolvedRef{Ref: ref, Err: err})
+		return nil
+	}
+	resolved = b.c.copy(reflect.ValueOf(resolved)).Interface()
+	if !ok {
+		return resolved // inline
+	}
+	name := b.componentName(kind, abs)
+	b.names[key] = name
+	b.doc.Components.add(name, resolved)
+	setRef(obj, "#/components/"+kind+"/"+name)
+	b.walk(resolved, joinPointer("/components", kind, name))
+	return nil
+}
+
+// absolute returns the absolute form of the reference. If the
+// reference points the root document itself, local reference is
+// returned as local instead.
+func (b *bundler) absolute(ref string) (abs *url.URL, local string) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, ""
+	}
+	if b.doc.location == nil {
+		return u, ""
+	}
+	abs = b.doc.location.ResolveReference(u)
+	location := *abs
+	location.Fragment = ""
+	if location.String() == b.doc.location.String() {
+		return nil, "#" + abs.Fragment
+	}
+	return abs, ""
+}
+
+// walk the object pulled into components to process the references in it.
+func (b *bundler) walk(obj interface{}, ptr string) {
+	switch o := obj.(type) {
+	case *Schema:
+		b.w.schema(&o, ptr)
+	case *Response:
+		b.w.response(&o, ptr)
+	case *Parameter:
+		b.w.parameter(&o, ptr)
+	case *RequestBody:
+		b.w.requestBody(&o, ptr)
+	case *Header:
+		b.w.header(&o, ptr)
+	case *PathItem:
+		b.w.pathItem(&o, ptr)
+	case *Example, *Link, *SecurityScheme:
+		b.w.replace(ptr, o)
+	}
+}
+
+// copier copies the objects deeply. The objects shared in the source,
+// including circular ones, are shared in the copy as well. The values
+// of interface{}, like examples and extensions, are not copied.
+type copier struct {
+	doc    *Document
+	copied map[copyKey]reflect.Value
+}
+
+type copyKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{v.Type(), v.Pointer()}
+		if copied, ok := c.copied[key]; ok {
+			return copied
+		}
+		ret := reflect.New(v.Type().Elem())
+		c.remember(key, ret)
+		ret.Elem().Set(c.copy(v.Elem()))
+		return ret
+	case reflect.Struct:
+		ret := reflect.New(v.Type()).Elem()
+		ret.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue // unexported
+			}
+			ret.Field(i).Set(c.copy(v.Field(i)))
+		}
+		return ret
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		ret := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return ret
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{v.Type(), v.Pointer()}
+		if copied, ok := c.copied[key]; ok {
+			return copied
+		}
+		ret := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.remember(key, ret)
+		iter := v.MapRange()
+		for iter.Next() {
+			ret.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		// keep the order and the extension of the map
+		info := c.doc.mapInfo(v)
+		if info.keys != nil || info.extension != nil {
+			c.doc.updateMapInfo(ret, func(copied *mapInfo) {
+				copied.keys = info.keys
+				if info.extension != nil {
+					copied.extension = copyExtension(info.extension)
+				}
+			})
+		}
+		return ret
+	}
+	return v
+}
+
+func (c *copier) remember(key copyKey, v reflect.Value) {
+	if c.copied == nil {
+		c.copied = map[copyKey]reflect.Value{}
+	}
+	c.copied[key] = v
+}
+
+var invalidComponentNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9\.\-_]+`)
+
+// componentName returns the name for the object referenced by abs,
+// which does not collide with the existing components.
+func (b *bundler) componentName(kind string, abs *url.URL) string {
+	base := abs.Fragment
+	if idx := strings.LastIndexByte(base, '/'); idx >= 0 {
+		base = unescapePointer(base[idx+1:])
+	}
+	if base == "" {
+		base = path.Base(abs.Path)
+		base = strings.TrimSuffix(base, path.Ext(base))
+	}
+	base = invalidComponentNameRegexp.ReplaceAllString(base, "_")
+	if base == "" {
+		base = kind
+	}
+	name := base
+	for i := 1; b.doc.Components.has(kind, name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+// componentKind returns the field name of components object for the
+// type of obj. If the object cannot be a component, ok is false.
+// Path items can be a component only in OAS 3.1.
+func (b *bundler) componentKind(obj interface{}) (kind string, ok bool) {
+	switch obj.(type) {
+	case *PathItem:
+		return "pathItems", b.doc.IsOAS31()
+	case *Schema:
+		return "schemas", true
+	case *Response:
+		return "responses", true
+	case *Parameter:
+		return "parameters", true
+	case *Example:
+		return "examples", true
+	case *RequestBody:
+		return "requestBodies", true
+	case *Header:
+		return "headers", true
+	case *SecurityScheme:
+		return "securitySchemes", true
+	case *Link:
+		return "links", true
+	}
+	return "", false
+}
+
+func (components *Components) has(kind, name string) bool {
+	_, err := components.resolve([]string{kind, name})
+	return err == nil
+}
+
+// add the object to components under the field for its type.
+func (components *Components) add(name string, obj interface{}) {
+	switch o := obj.(type) {
+	case *Schema:
+		if components.Schemas == nil {
+			components.Schemas = map[string]*Schema{}
+		}
+		components.Schemas[name] = o
+	case *Response:
+		if components.Responses == nil {
+			components.Responses = map[string]*Response{}
+		}
+		components.Responses[name] = o
+	case *Parameter:
+		if components.Parameters == nil {
+			components.Parameters = map[string]*Parameter{}
+		}
+		components.Parameters[name] = o
+	case *Example:
+		if components.Examples == nil {
+			components.Examples = map[string]*Example{}
+		}
+		components.Examples[name] = o
+	case *RequestBody:
+		if components.RequestBodies == nil {
+			components.RequestBodies = map[string]*RequestBody{}
+		}
+		components.RequestBodies[name] = o
+	case *Header:
+		if components.Headers == nil {
+			components.Headers = map[string]*Header{}
+		}
+		components.Headers[name] = o
+	case *SecurityScheme:
+		if components.SecuritySchemes == nil {
+			components.SecuritySchemes = map[string]*SecurityScheme{}
+		}
+		components.SecuritySchemes[name] = o
+	case *Link:
+		if components.Links == nil {
+			components.Links = map[string]*Link{}
+		}
+		components.Links[name] = o
+	case *PathItem:
+		if components.PathItems == nil {
+			components.PathItems = map[string]*PathItem{}
+		}
+		components.PathItems[name] = o
+	}
+}
+
+// setRef sets the value of $ref of the object.
+func setRef(obj interface{}, ref string) {
+	reflect.ValueOf(obj).Elem().FieldByName("Ref").SetString(ref)
+}
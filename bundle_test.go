@@ -0,0 +1,122 @@
+package openapi_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestDocument_Bundle(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Bundle(); err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/pets"].Get
+	candidates := []struct {
+		label string
+		ref   string
+		want  string
+	}{
+		{"response schema", op.Responses["200"].Content["application/json"].Schema.Items.Ref, "#/components/schemas/Pet"},
+		{"component schema", doc.Components.Schemas["Pets"].Items.Ref, "#/components/schemas/Pet"},
+		{"response", op.Responses["default"].Ref, "#/components/responses/error"},
+		{"nested", doc.Components.Schemas["Pet"].Properties["category"].Ref, "#/components/schemas/Category"},
+		{"whole file", doc.Components.Schemas["Pet"].Properties["tag"].Ref, "#/components/schemas/tag"},
+		{"in response", doc.Components.Responses["error"].Content["application/json"].Schema.Ref, "#/components/schemas/Category"},
+	}
+	for _, c := range candidates {
+		if c.ref != c.want {
+			t.Errorf("%s: %s != %s", c.label, c.ref, c.want)
+		}
+	}
+	if doc.Components.Schemas["tag"].Type != "string" {
+		t.Errorf("unexpected tag schema: %+v", doc.Components.Schemas["tag"])
+	}
+	if err := doc.Dereference(); err != nil {
+		t.Errorf("bundled document should be resolvable by itself: %s", err)
+	}
+}
+
+func TestDocument_BundleSharedLoader(t *testing.T) {
+	loader := &openapi.Loader{FS: os.DirFS("testdata/external")}
+	doc1, err := loader.Load("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc2, err := loader.Load("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc1.Components.Schemas["Category"] = &openapi.Schema{Type: "string"}
+	if err := doc1.Bundle(); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc2.Bundle(); err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		label string
+		ref   string
+		want  string
+	}{
+		{"first", doc1.Components.Schemas["Pet"].Properties["category"].Ref, "#/components/schemas/Category1"},
+		{"second", doc2.Components.Schemas["Pet"].Properties["category"].Ref, "#/components/schemas/Category"},
+	}
+	for _, c := range candidates {
+		if c.ref != c.want {
+			t.Errorf("%s: %s != %s", c.label, c.ref, c.want)
+		}
+	}
+	if doc1.Components.Schemas["Pet"] == doc2.Components.Schemas["Pet"] {
+		t.Error("bundled objects should not be shared between documents")
+	}
+	want := []string{"id", "name", "category", "tag"}
+	if got := doc2.PropertyKeys(doc2.Components.Schemas["Pet"]); !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+	if err := doc2.Dereference(); err != nil {
+		t.Errorf("bundled document should be resolvable by itself: %s", err)
+	}
+}
+
+func TestDocument_BundleCollision(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Components.Schemas["Pet"] = &openapi.Schema{Type: "string"}
+	if err := doc.Bundle(); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Components.Schemas["Pet"].Type != "string" {
+		t.Error("existing component should not be overwritten")
+	}
+	items := doc.Components.Schemas["Pets"].Items
+	if items.Ref != "#/components/schemas/Pet1" {
+		t.Errorf("%s != #/components/schemas/Pet1", items.Ref)
+	}
+	if doc.Components.Schemas["Pet1"].Type != "object" {
+		t.Errorf("unexpected schema: %+v", doc.Components.Schemas["Pet1"])
+	}
+}
+
+func TestDocument_BundleUnresolved(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Components.Schemas["Unknown"] = &openapi.Schema{Ref: "unknown.yaml#/Unknown"}
+	err = doc.Bundle()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs[0].Pointer != "/components/schemas/Unknown" {
+		t.Errorf("unexpected pointer: %s", errs[0].Pointer)
+	}
+}
@@ -11,15 +11,18 @@ type Callback map[string]*PathItem
 
 // Validate the values of Callback object.
 func (callback Callback) Validate() error {
+	return validateFirst(callback)
+}
+
+func (callback Callback) validate(v *validator, ptr string) {
 	for key, pathItem := range callback {
 		if !matchRuntimeExpression(key) {
-			return ErrRuntimeExprFormat
+			v.report(joinPointer(ptr, key), ErrRuntimeExprFormat)
 		}
-		if err := pathItem.Validate(); err != nil {
-			return err
+		if pathItem != nil {
+			pathItem.validate(v, joinPointer(ptr, key))
 		}
 	}
-	return nil
 }
 
 const (
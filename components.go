@@ -13,15 +13,81 @@ type Components struct {
 	SecuritySchemes map[string]*SecurityScheme `yaml:"securitySchemes"`
 	Links           map[string]*Link
 	Callbacks       map[string]*Callback
+	PathItems       map[string]*PathItem `yaml:"pathItems"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Components object.
 func (components Components) Validate() error {
+	return validateFirst(components)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (components Components) GetExtension(name string, v interface{}) error {
+	return getExtension(components.Extension, name, v)
+}
+
+func (components Components) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, components.Extension)
 	if err := validateComponentKeys(components); err != nil {
-		return err
+		v.report(ptr, err)
+	}
+	for name, schema := range components.Schemas {
+		if schema != nil {
+			schema.validate(v, joinPointer(ptr, "schemas", name))
+		}
+	}
+	for name, response := range components.Responses {
+		if response != nil {
+			response.validate(v, joinPointer(ptr, "responses", name))
+		}
+	}
+	for name, parameter := range components.Parameters {
+		if parameter != nil {
+			parameter.validate(v, joinPointer(ptr, "parameters", name))
+		}
+	}
+
+	for name, example := range components.Examples {
+		if example != nil {
+			example.validate(v, joinPointer(ptr, "examples", name))
+		}
+	}
+	for name, reqBody := range components.RequestBodies {
+		if reqBody != nil {
+			reqBody.validate(v, joinPointer(ptr, "requestBodies", name))
+		}
+	}
+	for name, header := range components.Headers {
+		if header != nil {
+			header.validate(v, joinPointer(ptr, "headers", name))
+		}
+	}
+	for name, secScheme := range components.SecuritySchemes {
+		if secScheme != nil {
+			secScheme.validate(v, joinPointer(ptr, "securitySchemes", name))
+		}
+	}
+	for name, link := range components.Links {
+		if link != nil {
+			link.validate(v, joinPointer(ptr, "links", name))
+		}
+	}
+	for name, callback := range components.Callbacks {
+		if callback != nil {
+			callback.validate(v, joinPointer(ptr, "callbacks", name))
+		}
+	}
+	if components.PathItems != nil && v.is30() {
+		v.reportUnsupported(ptr, "pathItems")
+	}
+	for name, pathItem := range components.PathItems {
+		if pathItem != nil {
+			pathItem.validate(v, joinPointer(ptr, "pathItems", name))
+		}
 	}
-	validaters := reduceComponentObjects(components)
-	return validateAll(validaters)
 }
 
 func validateComponentKeys(components Components) error {
@@ -63,37 +129,8 @@ func reduceComponentKeys(components Components) []string {
 	for k := range components.Callbacks {
 		keys = append(keys, k)
 	}
-	return keys
-}
-
-func reduceComponentObjects(components Components) []validater {
-	validaters := []validater{}
-	for _, schema := range components.Schemas {
-		validaters = append(validaters, schema)
-	}
-	for _, response := range components.Responses {
-		validaters = append(validaters, response)
-	}
-	for _, parameter := range components.Parameters {
-		validaters = append(validaters, parameter)
-	}
-
-	// example has no validation
-
-	for _, reqBody := range components.RequestBodies {
-		validaters = append(validaters, reqBody)
-	}
-	for _, header := range components.Headers {
-		validaters = append(validaters, header)
-	}
-	for _, secScheme := range components.SecuritySchemes {
-		validaters = append(validaters, secScheme)
-	}
-	for _, link := range components.Links {
-		validaters = append(validaters, link)
-	}
-	for _, callback := range components.Callbacks {
-		validaters = append(validaters, callback)
+	for k := range components.PathItems {
+		keys = append(keys, k)
 	}
-	return validaters
+	return keys
 }
@@ -52,24 +52,6 @@ func TestReduceComponentKeys(t *testing.T) {
 	}
 }
 
-func TestReduceComponentObjects(t *testing.T) {
-	candidates := []struct {
-		label    string
-		in       openapi.Components
-		expected []openapi.Validater
-	}{
-		{"empty", openapi.Components{}, []openapi.Validater{}},
-	}
-	for i, c := range candidates {
-		t.Run(strconv.Itoa(i)+"/"+c.label, func(t *testing.T) {
-			objects := openapi.ReduceComponentObjects(c.in)
-			if !reflect.DeepEqual(objects, c.expected) {
-				t.Errorf("%+v != %+v", objects, c.expected)
-			}
-		})
-	}
-}
-
 func TestComponentsByExample(t *testing.T) {
 	example := `schemas:
   GeneralError:
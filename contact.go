@@ -7,17 +7,29 @@ type Contact struct {
 	Name  string
 	URL   string
 	Email string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Contact object.
 func (contact Contact) Validate() error {
+	return validateFirst(contact)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (contact Contact) GetExtension(name string, v interface{}) error {
+	return getExtension(contact.Extension, name, v)
+}
+
+func (contact Contact) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, contact.Extension)
 	if err := mustURL("contact.url", contact.URL); err != nil {
-		return err
+		v.report(joinPointer(ptr, "url"), err)
 	}
 	if contact.Email != "" {
 		if !emailRegexp.MatchString(contact.Email) {
-			return ErrFormatInvalid{Target: "contact.email", Format: "email"}
+			v.report(joinPointer(ptr, "email"), ErrFormatInvalid{Target: "contact.email", Format: "email"})
 		}
 	}
-	return nil
 }
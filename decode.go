@@ -0,0 +1,474 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Position represents a location in the source of a document.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is valid.
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+func (pos Position) String() string {
+	s := pos.File
+	if pos.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
+}
+
+// obsoleteUnmarshaler is the yaml.v2 style unmarshaler interface,
+// which is implemented by some objects in this package.
+type obsoleteUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+}
+
+// decoder decodes a YAML node tree into the object model,
+// recording the position of each object and field, and the order
+// of the keys of each map.
+type decoder struct {
+	file      string
+	positions map[string]Position
+	maps      mapInfos
+
+	// strict makes the decoder report the unknown fields of the
+	// objects into unknown.
+	strict  bool
+	unknown ErrorList
+	// version is the minor version of the document, like "3.1".
+	// In OAS 3.1, schema objects can have any JSON Schema keywords,
+	// so they are not reported as unknown.
+	version string
+}
+
+var schemaType = reflect.TypeOf(Schema{})
+
+// documentVersion returns the minor version of the document from the
+// openapi field of the root node.
+func documentVersion(node *yaml.Node) string {
+	version, err := lookupNode(node, "/openapi")
+	if err != nil {
+		return ""
+	}
+	return minorVersion(version.Value)
+}
+
+func newDecoder(file string) *decoder {
+	return &decoder{
+		file:      file,
+		positions: map[string]Position{},
+		maps:      mapInfos{},
+	}
+}
+
+// unknownFields returns the unknown fields found in strict mode as
+// ErrorList, or nil if there is none.
+func (d *decoder) unknownFields() error {
+	if len(d.unknown) == 0 {
+		return nil
+	}
+	return d.unknown
+}
+
+func (d *decoder) position(node *yaml.Node) Position {
+	return Position{File: d.file, Line: node.Line, Column: node.Column}
+}
+
+func (d *decoder) errorf(node *yaml.Node, ptr string, format string, args ...interface{}) error {
+	return LocatedError{
+		Pointer:  ptr,
+		Position: d.position(node),
+		Err:      fmt.Errorf(format, args...),
+	}
+}
+
+func (d *decoder) decode(node *yaml.Node, out reflect.Value, ptr string) error {
+	switch node.Kind {
+	case 0:
+		return nil // empty document
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return d.decode(node.Content[0], out, ptr)
+	case yaml.AliasNode:
+		return d.decode(node.Alias, out, ptr)
+	}
+	if _, ok := d.positions[ptr]; !ok {
+		d.positions[ptr] = d.position(node)
+	}
+
+	if out.CanAddr() {
+		if u, ok := out.Addr().Interface().(obsoleteUnmarshaler); ok {
+			return u.UnmarshalYAML(func(v interface{}) error {
+				return d.decode(node, reflect.ValueOf(v).Elem(), ptr)
+			})
+		}
+	}
+
+	switch out.Kind() {
+	case reflect.Ptr:
+		if isNull(node) {
+			out.Set(reflect.Zero(out.Type()))
+			return nil
+		}
+		if out.IsNil() {
+			out.Set(reflect.New(out.Type().Elem()))
+		}
+		return d.decode(node, out.Elem(), ptr)
+	case reflect.Interface:
+		return d.decodeValue(node, out, ptr)
+	case reflect.Struct:
+		return d.decodeStruct(node, out, ptr)
+	case reflect.Map:
+		return d.decodeMap(node, out, ptr)
+	case reflect.Slice:
+		return d.decodeSlice(node, out, ptr)
+	}
+	if node.Kind != yaml.ScalarNode {
+		return d.errorf(node, ptr, "cannot unmarshal %s into %s", node.ShortTag(), out.Type())
+	}
+	if err := node.Decode(out.Addr().Interface()); err != nil {
+		return d.errorf(node, ptr, "cannot unmarshal %s `%s` into %s", node.ShortTag(), node.Value, out.Type())
+	}
+	return nil
+}
+
+// decodeValue decodes a free-form value, like an example.
+func (d *decoder) decodeValue(node *yaml.Node, out reflect.Value, ptr string) error {
+	v, err := d.value(node, ptr)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+	out.Set(reflect.ValueOf(v))
+	return nil
+}
+
+// value returns the free-form value of the node. The mappings are
+// converted into map[string]interface{}, same as encoding/json does,
+// and timestamps are kept as string.
+func (d *decoder) value(node *yaml.Node, ptr string) (interface{}, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return d.value(node.Alias, ptr)
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		var keys []interface{}
+		err := d.eachPair(node, func(key, value *yaml.Node) error {
+			if key.Kind != yaml.ScalarNode {
+				return d.errorf(key, ptr, "mapping key must be a scalar")
+			}
+			childPtr := joinPointer(ptr, key.Value)
+			d.positions[childPtr] = d.position(key)
+			v, err := d.value(value, childPtr)
+			if err != nil {
+				return err
+			}
+			m[key.Value] = v
+			keys = append(keys, key.Value)
+			return nil
+		})
+		d.maps.recordKeys(reflect.ValueOf(m), keys)
+		return m, err
+	case yaml.SequenceNode:
+		s := make([]interface{}, len(node.Content))
+		for i, n := range node.Content {
+			childPtr := joinPointer(ptr, strconv.Itoa(i))
+			d.positions[childPtr] = d.position(n)
+			v, err := d.value(n, childPtr)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = v
+		}
+		return s, nil
+	}
+	if node.ShortTag() == "!!timestamp" {
+		return node.Value, nil
+	}
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return nil, d.errorf(node, ptr, "%s", err)
+	}
+	return v, nil
+}
+
+type fieldInfo struct {
+	index []int
+	// alt is the index of the alternative field for the same key,
+	// which is used when the value cannot be decoded into the field.
+	alt []int
+}
+
+// structFields returns the fields of given struct type keyed by their
+// YAML key. The key of the field for inline map is empty.
+func structFields(typ reflect.Type) map[string]fieldInfo {
+	fields := map[string]fieldInfo{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if name, ok := fieldKey(field); ok {
+			if name == "" && field.Type.Kind() == reflect.Struct {
+				// the fields of inline struct are decoded as the
+				// fields of the outer struct
+				for name, info := range structFields(field.Type) {
+					if info.index != nil {
+						info.index = append([]int{i}, info.index...)
+					}
+					if info.alt != nil {
+						info.alt = append([]int{i}, info.alt...)
+					}
+					fields[name] = info
+				}
+				continue
+			}
+			info := fields[name]
+			info.index = field.Index
+			fields[name] = info
+		}
+		if name, ok := altFieldKey(field); ok {
+			info := fields[name]
+			info.alt = field.Index
+			fields[name] = info
+		}
+	}
+	return fields
+}
+
+// altFieldKey returns the key of the field which is an alternative
+// representation of another field, like a type array of a schema in
+// OAS 3.1. The key is given by openapi tag, as yaml.v2 does not allow
+// the duplicated keys.
+func altFieldKey(field reflect.StructField) (key string, ok bool) {
+	if field.PkgPath != "" {
+		return "", false // unexported
+	}
+	key = field.Tag.Get("openapi")
+	return key, key != ""
+}
+
+// fieldKey returns the YAML key of the struct field, which is empty
+// for inline map and struct. If the field is not serialized, ok is false.
+func fieldKey(field reflect.StructField) (key string, ok bool) {
+	if field.PkgPath != "" {
+		return "", false // unexported
+	}
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false
+	}
+	name, opts := tag, ""
+	if idx := strings.IndexByte(tag, ','); idx >= 0 {
+		name, opts = tag[:idx], tag[idx+1:]
+	}
+	if strings.Contains(opts, "inline") {
+		return "", true
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, true
+}
+
+func (d *decoder) decodeStruct(node *yaml.Node, out reflect.Value, ptr string) error {
+	if isNull(node) {
+		return nil
+	}
+	if d.version == "3.1" && out.Type() == schemaType && isBool(node) {
+		// boolean schema of JSON Schema 2020-12
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return d.errorf(node, ptr, "%s", err)
+		}
+		out.Set(reflect.ValueOf(Schema{Bool: &b}))
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return d.errorf(node, ptr, "cannot unmarshal %s into %s", node.ShortTag(), out.Type())
+	}
+	fields := structFields(out.Type())
+	var inlineKeys []interface{}
+	err := d.eachPair(node, func(key, value *yaml.Node) error {
+		childPtr := joinPointer(ptr, key.Value)
+		d.positions[childPtr] = d.position(key)
+		if field, ok := fields[key.Value]; ok && key.Value != "" {
+			return d.decodeField(value, out, field, childPtr)
+		}
+		if d.strict && !strings.HasPrefix(key.Value, extensionPrefix) && !(d.version == "3.1" && out.Type() == schemaType) {
+			d.unknown = append(d.unknown, LocatedError{
+				Pointer:  childPtr,
+				Position: d.position(key),
+				Err:      ErrUnknownField{Name: key.Value},
+			})
+		}
+		if field, ok := fields[""]; ok {
+			m := out.FieldByIndex(field.index)
+			if m.IsNil() {
+				m.Set(reflect.MakeMap(m.Type()))
+			}
+			elem := reflect.New(m.Type().Elem()).Elem()
+			if err := d.decode(value, elem, childPtr); err != nil {
+				return err
+			}
+			k := reflect.ValueOf(key.Value).Convert(m.Type().Key())
+			m.SetMapIndex(k, elem)
+			inlineKeys = append(inlineKeys, k.Interface())
+		}
+		return nil
+	})
+	if field, ok := fields[""]; ok && len(inlineKeys) > 0 {
+		d.maps.recordKeys(out.FieldByIndex(field.index), inlineKeys)
+	}
+	return err
+}
+
+// decodeField decodes the node into the field of the struct out. If
+// the node cannot be decoded into the field, it is decoded into the
+// alternative field if exists.
+func (d *decoder) decodeField(node *yaml.Node, out reflect.Value, field fieldInfo, ptr string) error {
+	if field.index == nil {
+		return d.decode(node, out.FieldByIndex(field.alt), ptr)
+	}
+	if field.alt != nil && isBool(node) && indirectKind(out.FieldByIndex(field.alt).Type()) == reflect.Bool {
+		// the boolean is for the alternative field, like
+		// additionalProperties, even if the field accepts it
+		return d.decode(node, out.FieldByIndex(field.alt), ptr)
+	}
+	f := out.FieldByIndex(field.index)
+	err := d.decode(node, f, ptr)
+	if err == nil || field.alt == nil {
+		return err
+	}
+	f.Set(reflect.Zero(f.Type()))
+	if d.decode(node, out.FieldByIndex(field.alt), ptr) != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *decoder) decodeMap(node *yaml.Node, out reflect.Value, ptr string) error {
+	if isNull(node) {
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return d.errorf(node, ptr, "cannot unmarshal %s into %s", node.ShortTag(), out.Type())
+	}
+	if out.IsNil() {
+		out.Set(reflect.MakeMap(out.Type()))
+	}
+	var keys []interface{}
+	defer func() { d.maps.recordKeys(out, keys) }()
+	return d.eachPair(node, func(key, value *yaml.Node) error {
+		childPtr := joinPointer(ptr, key.Value)
+		d.positions[childPtr] = d.position(key)
+		if isExtensibleMap(out.Type()) && strings.HasPrefix(key.Value, extensionPrefix) {
+			v, err := d.value(value, childPtr)
+			if err != nil {
+				return err
+			}
+			d.maps.recordExtension(out, key.Value, v)
+			return nil
+		}
+		k := reflect.New(out.Type().Key()).Elem()
+		if err := key.Decode(k.Addr().Interface()); err != nil {
+			return d.errorf(key, childPtr, "cannot unmarshal %s `%s` into %s", key.ShortTag(), key.Value, k.Type())
+		}
+		elem := reflect.New(out.Type().Elem()).Elem()
+		if err := d.decode(value, elem, childPtr); err != nil {
+			return err
+		}
+		out.SetMapIndex(k, elem)
+		keys = append(keys, k.Interface())
+		return nil
+	})
+}
+
+// eachPair calls fn for each key-value pair of the mapping node,
+// expanding merge keys.
+func (d *decoder) eachPair(node *yaml.Node, fn func(key, value *yaml.Node) error) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			if err := d.eachMerged(value, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *decoder) eachMerged(node *yaml.Node, fn func(key, value *yaml.Node) error) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return d.eachMerged(node.Alias, fn)
+	case yaml.MappingNode:
+		return d.eachPair(node, fn)
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			if err := d.eachMerged(n, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return d.errorf(node, "", "map merge requires map or sequence of maps as the value")
+}
+
+func (d *decoder) decodeSlice(node *yaml.Node, out reflect.Value, ptr string) error {
+	if isNull(node) {
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return d.errorf(node, ptr, "cannot unmarshal %s into %s", node.ShortTag(), out.Type())
+	}
+	s := reflect.MakeSlice(out.Type(), len(node.Content), len(node.Content))
+	for i, n := range node.Content {
+		if err := d.decode(n, s.Index(i), joinPointer(ptr, strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	out.Set(s)
+	return nil
+}
+
+func isBool(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool"
+}
+
+func indirectKind(typ reflect.Type) reflect.Kind {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind()
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
@@ -0,0 +1,105 @@
+package openapi_test
+
+import (
+	"strconv"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestPosition_String(t *testing.T) {
+	candidates := []struct {
+		label string
+		in    openapi.Position
+		want  string
+	}{
+		{"empty", openapi.Position{}, "-"},
+		{"fileOnly", openapi.Position{File: "petstore.yaml"}, "petstore.yaml"},
+		{"lineAndColumn", openapi.Position{Line: 42, Column: 7}, "42:7"},
+		{"full", openapi.Position{File: "petstore.yaml", Line: 42, Column: 7}, "petstore.yaml:42:7"},
+	}
+	for i, c := range candidates {
+		t.Run(strconv.Itoa(i)+"/"+c.label, func(t *testing.T) {
+			if got := c.in.String(); got != c.want {
+				t.Errorf("%s != %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDocument_Position(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		ptr  string
+		want openapi.Position
+	}{
+		{"", openapi.Position{File: "testdata/petstore.yaml", Line: 1, Column: 1}},
+		{"/info/title", openapi.Position{File: "testdata/petstore.yaml", Line: 4, Column: 3}},
+		{"/servers/0", openapi.Position{File: "testdata/petstore.yaml", Line: 8, Column: 5}},
+		{"/paths/~1pets/get/parameters/0/in", openapi.Position{File: "testdata/petstore.yaml", Line: 18, Column: 11}},
+		{"/paths/~1pets/get/responses/200", openapi.Position{File: "testdata/petstore.yaml", Line: 25, Column: 9}},
+	}
+	for _, c := range candidates {
+		t.Run(c.ptr, func(t *testing.T) {
+			got, ok := doc.Position(c.ptr)
+			if !ok {
+				t.Fatal("position is not recorded")
+			}
+			if got != c.want {
+				t.Errorf("%s != %s", got, c.want)
+			}
+		})
+	}
+	if _, ok := doc.Position("/paths/~1unknown"); ok {
+		t.Error("position of unknown pointer should not be found")
+	}
+}
+
+func TestLoad_ErrorPosition(t *testing.T) {
+	_, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: foo
+  version: 1.0
+paths:
+  /:
+    get:
+      parameters: foo
+`))
+	lerr, ok := err.(openapi.LocatedError)
+	if !ok {
+		t.Fatalf("error should be LocatedError, but %T: %v", err, err)
+	}
+	if lerr.Pointer != "/paths/~1/get/parameters" {
+		t.Errorf("unexpected pointer: %s", lerr.Pointer)
+	}
+	if lerr.Position.Line != 8 || lerr.Position.Column != 19 {
+		t.Errorf("unexpected position: %s", lerr.Position)
+	}
+}
+
+func TestDocument_ValidateAllPosition(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: foo
+  version: 1.0
+paths:
+  /:
+    get:
+      responses:
+        '200':
+          content: {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, ok := doc.ValidateAll().(openapi.ErrorList)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if want := "9:9: /paths/~1/get/responses/200: response.description is required"; errs[0].Error() != want {
+		t.Errorf("%s != %s", errs[0].Error(), want)
+	}
+}
@@ -0,0 +1,359 @@
+package openapi
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dereference replaces all the reference objects in the document with
+// the objects they point to, so the document can be used without
+// resolving references. The same object is shared by all the places
+// referencing it, so recursive schemas become circular pointers.
+// In OAS 3.1, a schema which has other keywords beside $ref is kept,
+// and the referenced schema is appended to its allOf instead.
+// If some references cannot be resolved, they are kept as they are and
+// returned as ErrorList, with the location of each reference.
+func (doc *Document) Dereference() error {
+	v := &validator{}
+	w := &refWalker{
+		onRef: func(ptr, ref string, obj interface{}) interface{} {
+			resolved, err := resolveChain(doc, ref, obj)
+			if err != nil {
+				v.report(ptr, ErrUnresolvedRef{Ref: ref, Err: err})
+				return nil
+			}
+			if s, ok := obj.(*Schema); ok && doc.IsOAS31() && hasSiblings(s) {
+				s.Ref = ""
+				s.AllOf = append(s.AllOf, resolved.(*Schema))
+				return nil
+			}
+			return resolved
+		},
+	}
+	w.document(doc)
+	doc.locate(v.errs)
+	return v.err()
+}
+
+// resolveChain resolves the reference, following the references
+// until it reaches the object which is not a reference. hint is a
+// pointer of the type of the referenced object.
+func resolveChain(root *Document, ref string, hint interface{}) (interface{}, error) {
+	seen := map[string]struct{}{}
+	for {
+		if _, ok := seen[ref]; ok {
+			return nil, errors.New("circular reference: " + ref)
+		}
+		seen[ref] = struct{}{}
+		obj, err := resolve(root, ref, hint)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.TypeOf(obj) != reflect.TypeOf(hint) {
+			return nil, ErrTypeAssertion
+		}
+		next := refOf(obj)
+		if next == "" {
+			return obj, nil
+		}
+		ref = next
+	}
+}
+
+// hasSiblings reports whether the schema has keywords beside $ref.
+func hasSiblings(schema *Schema) bool {
+	s := *schema
+	s.Ref = ""
+	return !reflect.ValueOf(s).IsZero()
+}
+
+// refOf returns the value of $ref of the object.
+func refOf(obj interface{}) string {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	ref := v.Elem().FieldByName("Ref")
+	if !ref.IsValid() {
+		return ""
+	}
+	return ref.String()
+}
+
+// refWalker walks all the objects in a document which can be a
+// reference object.
+type refWalker struct {
+	// onRef is called for each object which has $ref, with the JSON
+	// pointer to the object. If onRef returns non-nil value, the object
+	// is replaced with the value, which must be the same type as obj.
+	onRef func(ptr, ref string, obj interface{}) interface{}
+
+	visited map[interface{}]struct{}
+}
+
+// enter reports whether the object should be walked, marking it as
+// visited. Each object is walked only once, even if it is shared.
+func (w *refWalker) enter(obj interface{}) bool {
+	if w.visited == nil {
+		w.visited = map[interface{}]struct{}{}
+	}
+	if _, ok := w.visited[obj]; ok {
+		return false
+	}
+	w.visited[obj] = struct{}{}
+	return true
+}
+
+func (w *refWalker) replace(ptr string, obj interface{}) interface{} {
+	ref := refOf(obj)
+	if ref == "" {
+		return nil
+	}
+	return w.onRef(ptr, ref, obj)
+}
+
+func (w *refWalker) document(doc *Document) {
+	for path := range doc.Paths {
+		pathItem := doc.Paths[path]
+		w.pathItem(&pathItem, joinPointer("", "paths", path))
+		doc.Paths[path] = pathItem
+	}
+	for name := range doc.Webhooks {
+		pathItem := doc.Webhooks[name]
+		w.pathItem(&pathItem, joinPointer("", "webhooks", name))
+		doc.Webhooks[name] = pathItem
+	}
+	if doc.Components != nil {
+		w.components(doc.Components, "/components")
+	}
+}
+
+func (w *refWalker) components(components *Components, ptr string) {
+	for name := range components.Schemas {
+		s := components.Schemas[name]
+		w.schema(&s, joinPointer(ptr, "schemas", name))
+		components.Schemas[name] = s
+	}
+	for name := range components.Responses {
+		r := components.Responses[name]
+		w.response(&r, joinPointer(ptr, "responses", name))
+		components.Responses[name] = r
+	}
+	for name := range components.Parameters {
+		p := components.Parameters[name]
+		w.parameter(&p, joinPointer(ptr, "parameters", name))
+		components.Parameters[name] = p
+	}
+	w.examples(components.Examples, joinPointer(ptr, "examples"))
+	for name := range components.RequestBodies {
+		r := components.RequestBodies[name]
+		w.requestBody(&r, joinPointer(ptr, "requestBodies", name))
+		components.RequestBodies[name] = r
+	}
+	w.headers(components.Headers, joinPointer(ptr, "headers"))
+	for name := range components.SecuritySchemes {
+		s := components.SecuritySchemes[name]
+		if r := w.replace(joinPointer(ptr, "securitySchemes", name), s); r != nil {
+			components.SecuritySchemes[name] = r.(*SecurityScheme)
+		}
+	}
+	w.links(components.Links, joinPointer(ptr, "links"))
+	for name, callback := range components.Callbacks {
+		w.callback(callback, joinPointer(ptr, "callbacks", name))
+	}
+	for name := range components.PathItems {
+		pathItem := components.PathItems[name]
+		w.pathItem(&pathItem, joinPointer(ptr, "pathItems", name))
+		components.PathItems[name] = pathItem
+	}
+}
+
+func (w *refWalker) pathItem(pathItem **PathItem, ptr string) {
+	if *pathItem == nil {
+		return
+	}
+	if r := w.replace(ptr, *pathItem); r != nil {
+		*pathItem = r.(*PathItem)
+	}
+	if !w.enter(*pathItem) {
+		return
+	}
+	for method, op := range (*pathItem).Operations() {
+		w.operation(op, joinPointer(ptr, strings.ToLower(method)))
+	}
+	w.parameters((*pathItem).Parameters, joinPointer(ptr, "parameters"))
+}
+
+func (w *refWalker) operation(operation *Operation, ptr string) {
+	w.parameters(operation.Parameters, joinPointer(ptr, "parameters"))
+	w.requestBody(&operation.RequestBody, joinPointer(ptr, "requestBody"))
+	for status := range operation.Responses {
+		r := operation.Responses[status]
+		w.response(&r, joinPointer(ptr, "responses", status))
+		operation.Responses[status] = r
+	}
+	for name, callback := range operation.Callbacks {
+		w.callback(callback, joinPointer(ptr, "callbacks", name))
+	}
+}
+
+func (w *refWalker) callback(callback *Callback, ptr string) {
+	if callback == nil || !w.enter(callback) {
+		return
+	}
+	for expr := range *callback {
+		pathItem := (*callback)[expr]
+		w.pathItem(&pathItem, joinPointer(ptr, expr))
+		(*callback)[expr] = pathItem
+	}
+}
+
+func (w *refWalker) parameters(parameters []*Parameter, ptr string) {
+	for i := range parameters {
+		w.parameter(&parameters[i], joinPointer(ptr, strconv.Itoa(i)))
+	}
+}
+
+func (w *refWalker) parameter(parameter **Parameter, ptr string) {
+	if *parameter == nil {
+		return
+	}
+	if r := w.replace(ptr, *parameter); r != nil {
+		*parameter = r.(*Parameter)
+	}
+	if !w.enter(*parameter) {
+		return
+	}
+	w.schema(&(*parameter).Schema, joinPointer(ptr, "schema"))
+	w.examples((*parameter).Examples, joinPointer(ptr, "examples"))
+	w.content((*parameter).Content, joinPointer(ptr, "content"))
+}
+
+func (w *refWalker) requestBody(requestBody **RequestBody, ptr string) {
+	if *requestBody == nil {
+		return
+	}
+	if r := w.replace(ptr, *requestBody); r != nil {
+		*requestBody = r.(*RequestBody)
+	}
+	if !w.enter(*requestBody) {
+		return
+	}
+	w.content((*requestBody).Content, joinPointer(ptr, "content"))
+}
+
+func (w *refWalker) response(response **Response, ptr string) {
+	if *response == nil {
+		return
+	}
+	if r := w.replace(ptr, *response); r != nil {
+		*response = r.(*Response)
+	}
+	if !w.enter(*response) {
+		return
+	}
+	w.headers((*response).Headers, joinPointer(ptr, "headers"))
+	w.content((*response).Content, joinPointer(ptr, "content"))
+	w.links((*response).Links, joinPointer(ptr, "links"))
+}
+
+func (w *refWalker) content(content map[string]*MediaType, ptr string) {
+	for mime, mediaType := range content {
+		if mediaType == nil || !w.enter(mediaType) {
+			continue
+		}
+		mtPtr := joinPointer(ptr, mime)
+		w.schema(&mediaType.Schema, joinPointer(mtPtr, "schema"))
+		w.examples(mediaType.Examples, joinPointer(mtPtr, "examples"))
+		for name, encoding := range mediaType.Encoding {
+			if encoding != nil {
+				w.headers(encoding.Headers, joinPointer(mtPtr, "encoding", name, "headers"))
+			}
+		}
+	}
+}
+
+func (w *refWalker) headers(headers map[string]*Header, ptr string) {
+	for name := range headers {
+		header := headers[name]
+		w.header(&header, joinPointer(ptr, name))
+		headers[name] = header
+	}
+}
+
+func (w *refWalker) header(header **Header, ptr string) {
+	if *header == nil {
+		return
+	}
+	if r := w.replace(ptr, *header); r != nil {
+		*header = r.(*Header)
+	}
+	if !w.enter(*header) {
+		return
+	}
+	w.schema(&(*header).Schema, joinPointer(ptr, "schema"))
+	w.examples((*header).Examples, joinPointer(ptr, "examples"))
+	w.content((*header).Content, joinPointer(ptr, "content"))
+}
+
+func (w *refWalker) examples(examples map[string]*Example, ptr string) {
+	for name, example := range examples {
+		if example == nil {
+			continue
+		}
+		if r := w.replace(joinPointer(ptr, name), example); r != nil {
+			examples[name] = r.(*Example)
+		}
+	}
+}
+
+func (w *refWalker) links(links map[string]*Link, ptr string) {
+	for name, link := range links {
+		if link == nil {
+			continue
+		}
+		if r := w.replace(joinPointer(ptr, name), link); r != nil {
+			links[name] = r.(*Link)
+		}
+	}
+}
+
+func (w *refWalker) schema(schema **Schema, ptr string) {
+	if *schema == nil {
+		return
+	}
+	if r := w.replace(ptr, *schema); r != nil {
+		*schema = r.(*Schema)
+	}
+	s := *schema
+	if !w.enter(s) {
+		return
+	}
+	for i := range s.AllOf {
+		w.schema(&s.AllOf[i], joinPointer(ptr, "allOf", strconv.Itoa(i)))
+	}
+	for i := range s.OneOf {
+		w.schema(&s.OneOf[i], joinPointer(ptr, "oneOf", strconv.Itoa(i)))
+	}
+	for i := range s.AnyOf {
+		w.schema(&s.AnyOf[i], joinPointer(ptr, "anyOf", strconv.Itoa(i)))
+	}
+	w.schema(&s.Not, joinPointer(ptr, "not"))
+	w.schema(&s.Items, joinPointer(ptr, "items"))
+	for name := range s.Properties {
+		property := s.Properties[name]
+		w.schema(&property, joinPointer(ptr, "properties", name))
+		s.Properties[name] = property
+	}
+	w.schema(&s.AdditionalProperties, joinPointer(ptr, "additionalProperties"))
+	for i := range s.PrefixItems {
+		w.schema(&s.PrefixItems[i], joinPointer(ptr, "prefixItems", strconv.Itoa(i)))
+	}
+	for name := range s.Defs {
+		def := s.Defs[name]
+		w.schema(&def, joinPointer(ptr, "$defs", name))
+		s.Defs[name] = def
+	}
+}
@@ -0,0 +1,211 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestDocument_Dereference(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: dereference
+  version: 1.0.0
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/id'
+    get:
+      responses:
+        '200':
+          description: ok
+          headers:
+            X-Session:
+              $ref: '#/components/headers/session'
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+              examples:
+                user:
+                  $ref: '#/components/examples/user'
+          links:
+            self:
+              $ref: '#/components/links/self'
+        default:
+          $ref: '#/components/responses/Error'
+    put:
+      requestBody:
+        $ref: '#/components/requestBodies/User'
+      responses:
+        '204':
+          description: no content
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          $ref: '#/components/schemas/ID'
+        friends:
+          type: array
+          items:
+            $ref: '#/components/schemas/User'
+    ID:
+      $ref: '#/components/schemas/UUID'
+    UUID:
+      type: string
+      format: uuid
+  parameters:
+    id:
+      name: id
+      in: path
+      required: true
+      schema:
+        $ref: '#/components/schemas/ID'
+  responses:
+    Error:
+      description: error
+  requestBodies:
+    User:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/User'
+  headers:
+    session:
+      schema:
+        type: string
+  examples:
+    user:
+      value:
+        id: foo
+  links:
+    self:
+      operationId: getUser
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Dereference(); err != nil {
+		t.Fatal(err)
+	}
+	components := doc.Components
+	user := components.Schemas["User"]
+	uuid := components.Schemas["UUID"]
+	pathItem := doc.Paths["/users/{id}"]
+	if pathItem.Parameters[0] != components.Parameters["id"] {
+		t.Error("parameter is not dereferenced")
+	}
+	if components.Parameters["id"].Schema != uuid {
+		t.Error("chained reference is not dereferenced")
+	}
+	if components.Schemas["ID"] != uuid {
+		t.Error("the reference in components is not dereferenced")
+	}
+	resp := pathItem.Get.Responses["200"]
+	if resp.Headers["X-Session"] != components.Headers["session"] {
+		t.Error("header is not dereferenced")
+	}
+	if resp.Content["application/json"].Schema != user {
+		t.Error("schema is not dereferenced")
+	}
+	if resp.Content["application/json"].Examples["user"] != components.Examples["user"] {
+		t.Error("example is not dereferenced")
+	}
+	if resp.Links["self"] != components.Links["self"] {
+		t.Error("link is not dereferenced")
+	}
+	if pathItem.Get.Responses["default"] != components.Responses["Error"] {
+		t.Error("response is not dereferenced")
+	}
+	if pathItem.Put.RequestBody != components.RequestBodies["User"] {
+		t.Error("requestBody is not dereferenced")
+	}
+	if user.Properties["id"] != uuid {
+		t.Error("property is not dereferenced")
+	}
+	if user.Properties["friends"].Items != user {
+		t.Error("recursive schema should be a circular pointer")
+	}
+}
+
+func TestDocument_DereferenceExternal(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Dereference(); err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/pets"].Get
+	pet := op.Responses["200"].Content["application/json"].Schema.Items
+	if pet.Ref != "" || pet.Properties["id"].Type != "integer" {
+		t.Errorf("external schema is not dereferenced: %+v", pet)
+	}
+	if pet != doc.Components.Schemas["Pets"].Items {
+		t.Error("the same external reference should be dereferenced to the same object")
+	}
+	category := pet.Properties["category"]
+	if category.Ref != "" || category.Properties["name"].Type != "string" {
+		t.Errorf("reference in external document is not dereferenced: %+v", category)
+	}
+	resp := op.Responses["default"]
+	if resp.Description != "unexpected error" {
+		t.Errorf("%s != unexpected error", resp.Description)
+	}
+	if resp.Content["application/json"].Schema != category {
+		t.Error("the same external reference should be dereferenced to the same object")
+	}
+}
+
+func TestDocument_DereferenceUnresolved(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: dereference
+  version: 1.0.0
+paths:
+  /:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Unknown'
+components:
+  schemas:
+    Loop:
+      $ref: '#/components/schemas/Loop'
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.Dereference()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var unresolved openapi.ErrUnresolvedRef
+	if !errors.As(errs[0], &unresolved) {
+		t.Fatalf("error should be ErrUnresolvedRef: %v", errs[0])
+	}
+	if errs[0].Pointer != "/paths/~1/get/responses/200/content/application~1json/schema" {
+		t.Errorf("unexpected pointer: %s", errs[0].Pointer)
+	}
+	if unresolved.Ref != "#/components/schemas/Unknown" {
+		t.Errorf("unexpected ref: %s", unresolved.Ref)
+	}
+	if errs[0].Position.Line != 13 {
+		t.Errorf("unexpected position: %s", errs[0].Position)
+	}
+	if errs[1].Pointer != "/components/schemas/Loop" {
+		t.Errorf("unexpected pointer: %s", errs[1].Pointer)
+	}
+	schema := doc.Paths["/"].Get.Responses["200"].Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/Unknown" {
+		t.Error("unresolved reference should be kept")
+	}
+}
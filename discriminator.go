@@ -10,8 +10,11 @@ type Discriminator struct {
 
 // Validate the values of Descriminator object.
 func (discriminator Discriminator) Validate() error {
+	return validateFirst(discriminator)
+}
+
+func (discriminator Discriminator) validate(v *validator, ptr string) {
 	if discriminator.PropertyName == "" {
-		return ErrRequired{Target: "discriminator.propertyName"}
+		v.report(ptr, ErrRequired{Target: "discriminator.propertyName"})
 	}
-	return nil
 }
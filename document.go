@@ -1,34 +1,188 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+
+	yaml "gopkg.in/yaml.v3"
 )
 
 // codebeat:disable[TOO_MANY_IVARS]
 
 // Document represents a OpenAPI Specification document.
 type Document struct {
-	Version      string `yaml:"openapi"`
-	Info         *Info
-	Servers      []*Server
-	Paths        Paths
-	Components   *Components
-	Security     []*SecurityRequirement
-	Tags         []*Tag
-	ExternalDocs *ExternalDocumentation `yaml:"externalDocs"`
+	Version           string `yaml:"openapi"`
+	Info              *Info
+	JSONSchemaDialect string `yaml:"jsonSchemaDialect"`
+	Servers           []*Server
+	Paths             Paths
+	Webhooks          map[string]*PathItem
+	Components        *Components
+	Security          []*SecurityRequirement
+	Tags              []*Tag
+	ExternalDocs      *ExternalDocumentation `yaml:"externalDocs"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+
+	// positions holds the source positions of the objects and fields,
+	// keyed by JSON pointer.
+	positions map[string]Position
+	// maps holds the information of the maps, like the order of the
+	// keys in the source.
+	maps mapInfos
+
+	// loader and location are set when the document is loaded by a
+	// Loader, to resolve the references to other documents.
+	loader   *Loader
+	location *url.URL
+	// formats is the registry of the formats to check the values. If
+	// nil, DefaultFormats is used.
+	formats *FormatRegistry
+}
+
+// Position returns the source position of the object or the field
+// which given JSON pointer points. If the document is not loaded from
+// a source or the position is not known, ok is false.
+func (doc *Document) Position(ptr string) (pos Position, ok bool) {
+	pos, ok = doc.positions[ptr]
+	return pos, ok
+}
+
+// locate sets the source positions to the errors.
+func (doc *Document) locate(errs ErrorList) {
+	for i := range errs {
+		errs[i].Position = doc.nearestPosition(errs[i].Pointer)
+	}
+}
+
+// nearestPosition returns the source position of the object which
+// given JSON pointer points, or of its nearest ancestor.
+func (doc *Document) nearestPosition(ptr string) Position {
+	for {
+		if pos, ok := doc.positions[ptr]; ok {
+			return pos
+		}
+		idx := strings.LastIndexByte(ptr, '/')
+		if idx < 0 {
+			return Position{}
+		}
+		ptr = ptr[:idx]
+	}
+}
+
+// MarshalYAML implements yaml.Marshaler of gopkg.in/yaml.v3.
+// The fields with empty value are omitted, and the keys of the maps
+// are ordered as in the source.
+func (doc Document) MarshalYAML() (interface{}, error) {
+	e := &encoder{doc: &doc}
+	return e.encodeStruct(reflect.ValueOf(doc))
+}
+
+// MarshalJSON implements json.Marshaler.
+// The fields with empty value are omitted.
+func (doc Document) MarshalJSON() ([]byte, error) {
+	node, err := doc.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, node.(*yaml.Node)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile writes the spec to the file. The spec is written as JSON
+// if the file name has .json extension, otherwise as YAML.
+func (doc Document) WriteFile(filename string) error {
+	return writeFile(filename, doc)
+}
+
+// writeFile writes v as JSON if the file name has .json extension,
+// otherwise as YAML.
+func writeFile(filename string, v interface{}) error {
+	var buf bytes.Buffer
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	} else {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// IsOAS31 reports whether the document is written in OpenAPI
+// Specification 3.1, whose semantics differ from 3.0 in some places.
+func (doc Document) IsOAS31() bool {
+	return minorVersion(doc.Version) == "3.1"
+}
+
+// minorVersion returns the OAS version in "major.minor" form, or empty
+// string if the version is not supported.
+func minorVersion(version string) string {
+	switch {
+	case strings.HasPrefix(version, "3.0."):
+		return "3.0"
+	case strings.HasPrefix(version, "3.1."):
+		return "3.1"
+	}
+	return ""
 }
 
 // Validate the values of spec.
+// This function returns the first error found. Use ValidateAll to
+// get all of them.
 func (doc Document) Validate() error {
+	return validateFirst(doc)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (doc Document) GetExtension(name string, v interface{}) error {
+	return getExtension(doc.Extension, name, v)
+}
+
+// ValidateAll validates the values of spec and returns all the errors
+// found as ErrorList. Each error has the JSON pointer to the object
+// where it is found.
+func (doc Document) ValidateAll() error {
+	v := &validator{}
+	doc.validate(v, "")
+	doc.locate(v.errs)
+	return v.err()
+}
+
+func (doc Document) validate(v *validator, ptr string) {
+	v.version = minorVersion(doc.Version)
+	v.doc = &doc
+	validateExtension(v, ptr, doc.Extension)
 	if err := doc.validateRequiredFields(); err != nil {
-		return err
+		v.report(ptr, err)
+		return
 	}
 	if err := doc.validateOASVersion(); err != nil {
-		return err
+		v.report(joinPointer(ptr, "openapi"), err)
+		return
 	}
-	return doc.validateFields()
+	doc.validateFields(v, ptr)
 }
 
 func (doc Document) validateOASVersion() error {
@@ -48,7 +202,7 @@ func (doc Document) validateOASVersion() error {
 	if err != nil {
 		return ErrFormatInvalid{Target: "patch part of openapi version"}
 	}
-	if major == 3 && 0 <= minor {
+	if major == 3 && 0 <= minor && minor <= 1 {
 		return nil
 	}
 	return ErrUnsupportedVersion
@@ -61,36 +215,137 @@ func (doc Document) validateRequiredFields() error {
 	if doc.Info == nil {
 		return ErrRequired{Target: "info"}
 	}
+	if doc.IsOAS31() {
+		if doc.Paths == nil && doc.Components == nil && doc.Webhooks == nil {
+			return ErrRequired{Target: "paths, components or webhooks"}
+		}
+		return nil
+	}
 	if doc.Paths == nil {
 		return ErrRequired{Target: "paths"}
 	}
 	return nil
 }
 
-func (doc Document) validateFields() error {
-	var validaters []validater
-	validaters = append(validaters, doc.Info)
-	for _, s := range doc.Servers {
-		validaters = append(validaters, s)
+func (doc Document) validateFields(v *validator, ptr string) {
+	doc.Info.validate(v, joinPointer(ptr, "info"))
+	for i, s := range doc.Servers {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "servers", strconv.Itoa(i)))
+		}
 	}
-	validaters = append(validaters, doc.Paths)
+	doc.Paths.validate(v, joinPointer(ptr, "paths"))
+	doc.validatePathParameters(v, joinPointer(ptr, "paths"))
+	doc.validateOAS31Fields(v, ptr)
 	if doc.Components != nil {
-		validaters = append(validaters, doc.Components)
+		doc.Components.validate(v, joinPointer(ptr, "components"))
 	}
-	for _, securityRequirement := range doc.Security {
-		validaters = append(validaters, securityRequirement)
+	for i, securityRequirement := range doc.Security {
+		if securityRequirement != nil {
+			securityRequirement.validate(v, joinPointer(ptr, "security", strconv.Itoa(i)))
+		}
 	}
-	for _, t := range doc.Tags {
-		validaters = append(validaters, t)
+	for i, t := range doc.Tags {
+		if t != nil {
+			t.validate(v, joinPointer(ptr, "tags", strconv.Itoa(i)))
+		}
 	}
 	if doc.ExternalDocs != nil {
-		validaters = append(validaters, doc.ExternalDocs)
+		doc.ExternalDocs.validate(v, joinPointer(ptr, "externalDocs"))
+	}
+}
+
+// validatePathParameters checks that the template variables of each
+// path correspond to the path parameters of its operations, which are
+// declared in the operation or in the path item.
+func (doc Document) validatePathParameters(v *validator, ptr string) {
+	for _, path := range doc.PathKeys() {
+		pathItem := doc.Paths[path]
+		vars, ok := pathTemplateVariables(path)
+		if pathItem == nil || !ok {
+			continue
+		}
+		pathPtr := joinPointer(ptr, path)
+		inTemplate := map[string]bool{}
+		for _, name := range vars {
+			inTemplate[name] = true
+		}
+		common, commonResolved := doc.pathParameterNames(v, pathItem.Parameters, inTemplate, joinPointer(pathPtr, "parameters"))
+		for _, method := range methods {
+			op := pathItem.GetOperationByMethod(method)
+			if op == nil {
+				continue
+			}
+			opPtr := joinPointer(pathPtr, strings.ToLower(method))
+			declared, resolved := doc.pathParameterNames(v, op.Parameters, inTemplate, joinPointer(opPtr, "parameters"))
+			if !commonResolved || !resolved {
+				continue // an unresolved parameter may declare any variable
+			}
+			for _, name := range vars {
+				if !declared[name] && !common[name] {
+					v.report(opPtr, ErrPathParameterNotDeclared{Name: name})
+				}
+			}
+		}
+	}
+}
+
+// pathParameterNames returns the names of the path parameters in the
+// list, reporting the ones which are not in the template. resolved is
+// false if some references cannot be resolved.
+func (doc Document) pathParameterNames(v *validator, parameters []*Parameter, inTemplate map[string]bool, ptr string) (names map[string]bool, resolved bool) {
+	names = map[string]bool{}
+	resolved = true
+	for i, parameter := range parameters {
+		if parameter == nil {
+			continue
+		}
+		if parameter.Ref != "" {
+			p, err := resolveChain(&doc, parameter.Ref, parameter)
+			if err != nil {
+				resolved = false
+				continue
+			}
+			parameter = p.(*Parameter)
+		}
+		if parameter.In != InPath {
+			continue
+		}
+		names[parameter.Name] = true
+		if !inTemplate[parameter.Name] {
+			v.report(joinPointer(ptr, strconv.Itoa(i)), ErrPathParameterNotInTemplate{Name: parameter.Name})
+		}
+	}
+	return names, resolved
+}
+
+func (doc Document) validateOAS31Fields(v *validator, ptr string) {
+	if v.is30() {
+		if doc.JSONSchemaDialect != "" {
+			v.reportUnsupported(ptr, "jsonSchemaDialect")
+		}
+		if doc.Webhooks != nil {
+			v.reportUnsupported(ptr, "webhooks")
+		}
+		return
+	}
+	if doc.JSONSchemaDialect != "" {
+		if u, err := url.Parse(doc.JSONSchemaDialect); err != nil || !u.IsAbs() {
+			v.report(joinPointer(ptr, "jsonSchemaDialect"), ErrFormatInvalid{Target: "jsonSchemaDialect", Format: "URI"})
+		}
+	}
+	for name, pathItem := range doc.Webhooks {
+		if pathItem != nil {
+			pathItem.validate(v, joinPointer(ptr, "webhooks", name))
+		}
 	}
-	return validateAll(validaters)
 }
 
 type WalkFunc func(doc *Document, method, path string, pathItem *PathItem, op *Operation) error
 
+// Walk calls walkFn for each operation in the document. The paths are
+// walked in sorted order, and the operations in a path are walked in
+// sorted order of the methods.
 func (doc *Document) Walk(walkFn WalkFunc) error {
 	var paths []string
 	for path := range doc.Paths {
@@ -99,18 +354,51 @@ func (doc *Document) Walk(walkFn WalkFunc) error {
 	sort.Strings(paths)
 
 	for _, path := range paths {
-		pathItem := doc.Paths[path]
-		var methods []string
-		for method := range pathItem.Operations() {
-			methods = append(methods, method)
+		if err := walkPathItem(doc, path, doc.Paths[path], walkFn); err != nil {
+			return err
 		}
-		sort.Strings(methods)
+	}
+	return nil
+}
 
-		for _, method := range methods {
-			operation := pathItem.GetOperationByMethod(method)
-			if err := walkFn(doc, method, path, pathItem, operation); err != nil {
-				return err
-			}
+// WalkInSourceOrder calls walkFn for each operation in the document
+// like Walk, but the paths are walked in the order of the source, as
+// PathKeys returns.
+func (doc *Document) WalkInSourceOrder(walkFn WalkFunc) error {
+	for _, path := range doc.PathKeys() {
+		if err := walkPathItem(doc, path, doc.Paths[path], walkFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkWebhooks calls walkFn for each operation of the webhooks in the
+// document, in the same way as WalkInSourceOrder. The name of the
+// webhook is passed as path.
+func (doc *Document) WalkWebhooks(walkFn WalkFunc) error {
+	for _, name := range doc.Keys(doc.Webhooks) {
+		if err := walkPathItem(doc, name, doc.Webhooks[name], walkFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkPathItem(doc *Document, path string, pathItem *PathItem, walkFn WalkFunc) error {
+	if pathItem == nil {
+		return nil
+	}
+	var methods []string
+	for method := range pathItem.Operations() {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		operation := pathItem.GetOperationByMethod(method)
+		if err := walkFn(doc, method, path, pathItem, operation); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -1,6 +1,7 @@
 package openapi_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -162,4 +163,160 @@ func TestDocument_Walk(t *testing.T) {
 	if err := doc.Walk(getWalkFn()); err != nil {
 		t.Error(err)
 	}
+	// the document not loaded from a source is walked in sorted order
+	if err := doc.WalkInSourceOrder(getWalkFn()); err != nil {
+		t.Error(err)
+	}
+
+	loaded, err := openapi.Load([]byte(orderedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	walkCandidates := []struct {
+		label string
+		walk  func(openapi.WalkFunc) error
+		want  []string
+	}{
+		{"sorted", loaded.Walk, []string{"/accounts", "/users"}},
+		{"source", loaded.WalkInSourceOrder, []string{"/users", "/accounts"}},
+	}
+	for _, c := range walkCandidates {
+		var paths []string
+		err := c.walk(func(doc *openapi.Document, method, path string, pathItem *openapi.PathItem, op *openapi.Operation) error {
+			paths = append(paths, path)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: %s", c.label, err)
+			continue
+		}
+		if !reflect.DeepEqual(paths, c.want) {
+			t.Errorf("%s: %v != %v", c.label, paths, c.want)
+		}
+	}
+}
+
+func TestDocument_ValidateAll(t *testing.T) {
+	doc := openapi.Document{
+		Version: "3.0.0",
+		Info:    &openapi.Info{Title: "foo"},
+		Paths: openapi.Paths{
+			"/pets": &openapi.PathItem{
+				Get: &openapi.Operation{
+					Responses: openapi.Responses{
+						"200": &openapi.Response{},
+					},
+				},
+			},
+		},
+		Tags: []*openapi.Tag{{Name: "foo"}, {}},
+	}
+	err := doc.ValidateAll()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList, but %T", err)
+	}
+	want := openapi.ErrorList{
+		{Pointer: "/info", Err: openapi.ErrRequired{Target: "info.version"}},
+		{Pointer: "/paths/~1pets/get/responses/200", Err: openapi.ErrRequired{Target: "response.description"}},
+		{Pointer: "/tags/1", Err: openapi.ErrRequired{Target: "tag.name"}},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("unexpected errors:\n  got:\t%+v\n  want:\t%+v", errs, want)
+	}
+	if err := doc.Validate(); err != want[0].Err {
+		t.Errorf("Validate should return the first error: %s", err)
+	}
+
+	doc.Info.Version = "1.0"
+	doc.Paths["/pets"].Get.Responses["200"].Description = "ok"
+	doc.Tags[1].Name = "bar"
+	if err := doc.ValidateAll(); err != nil {
+		t.Errorf("error should not be occurred: %s", err)
+	}
+}
+
+func TestLocatedError(t *testing.T) {
+	err := openapi.LocatedError{Pointer: "/paths/~1pets", Err: openapi.ErrPathFormat}
+	if err.Error() != "/paths/~1pets: path format is invalid" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+	if !errors.Is(err, openapi.ErrPathFormat) {
+		t.Error("LocatedError should wrap the error")
+	}
+}
+
+func TestDocument_ValidateAll_PathParameters(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: path parameters
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+  /orgs/{orgId}/members/{memberId}:
+    parameters:
+      - $ref: '#/components/parameters/orgId'
+    get:
+      operationId: getMembers
+      parameters:
+        - name: memberId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+    delete:
+      operationId: deleteMembers
+      responses:
+        '204':
+          description: deleted
+  /a/{b:
+    get:
+      operationId: getA
+      responses:
+        '200':
+          description: ok
+components:
+  parameters:
+    orgId:
+      name: orgId
+      in: path
+      required: true
+      schema:
+        type: string
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateAll()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList, but %T", err)
+	}
+	got := map[string]error{}
+	for _, e := range errs {
+		got[e.Pointer] = e.Err
+	}
+	want := map[string]error{
+		"/paths/~1a~1{b":                                     openapi.ErrPathTemplateFormat,
+		"/paths/~1users~1{userId}/get":                       openapi.ErrPathParameterNotDeclared{Name: "userId"},
+		"/paths/~1users~1{userId}/get/parameters/0":          openapi.ErrPathParameterNotInTemplate{Name: "id"},
+		"/paths/~1orgs~1{orgId}~1members~1{memberId}/delete": openapi.ErrPathParameterNotDeclared{Name: "memberId"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected errors:\n  got:\t%+v\n  want:\t%+v", got, want)
+	}
 }
@@ -0,0 +1,109 @@
+package openapi
+
+// EffectiveParameters returns the parameters of the operation for the
+// method of the path, including the ones declared in the path item.
+// The parameters of the operation override the ones of the path item
+// which have the same name and location. The references are resolved.
+// If the path is not in the document, ErrPathNotFound is returned,
+// and if the path has no operation for the method, ErrMethodNotAllowed
+// is returned.
+func (doc *Document) EffectiveParameters(path, method string) ([]*Parameter, error) {
+	pathItem, op, err := doc.lookupOperation(path, method)
+	if err != nil {
+		return nil, err
+	}
+	return doc.mergeParameters(pathItem, op)
+}
+
+// EffectiveServers returns the servers of the operation for the
+// method of the path. The servers of the operation override the ones
+// of the path item, which override the ones of the document. If none
+// of them has servers, a server with the url / is returned. The errors
+// are same as EffectiveParameters.
+func (doc *Document) EffectiveServers(path, method string) ([]*Server, error) {
+	pathItem, op, err := doc.lookupOperation(path, method)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(op.Servers) > 0:
+		return op.Servers, nil
+	case len(pathItem.Servers) > 0:
+		return pathItem.Servers, nil
+	case len(doc.Servers) > 0:
+		return doc.Servers, nil
+	}
+	return []*Server{{URL: "/"}}, nil
+}
+
+// EffectiveSecurity returns the security requirements of the operation
+// for the method of the path. The security of the operation overrides
+// the one of the document, even if it is an empty list which removes
+// the security. The errors are same as EffectiveParameters.
+func (doc *Document) EffectiveSecurity(path, method string) ([]*SecurityRequirement, error) {
+	_, op, err := doc.lookupOperation(path, method)
+	if err != nil {
+		return nil, err
+	}
+	if op.Security != nil {
+		return op.Security, nil
+	}
+	return doc.Security, nil
+}
+
+// lookupOperation returns the path item of the path, resolving the
+// reference, and its operation for the method.
+func (doc *Document) lookupOperation(path, method string) (*PathItem, *Operation, error) {
+	pathItem, ok := doc.Paths[path]
+	if !ok || pathItem == nil {
+		return nil, nil, ErrPathNotFound
+	}
+	if pathItem.Ref != "" {
+		resolved, err := resolveChain(doc, pathItem.Ref, pathItem)
+		if err != nil {
+			return nil, nil, ErrUnresolvedRef{Ref: pathItem.Ref, Err: err}
+		}
+		pathItem = resolved.(*PathItem)
+	}
+	op := pathItem.GetOperationByMethod(method)
+	if op == nil {
+		return nil, nil, ErrMethodNotAllowed
+	}
+	return pathItem, op, nil
+}
+
+// mergeParameters returns the parameters of the operation merged with
+// the ones of the path item, resolving the references. The operation
+// parameters override the path item ones which have the same name and
+// location. If some references cannot be resolved, the other
+// parameters are returned with the error.
+func (doc *Document) mergeParameters(pathItem *PathItem, op *Operation) ([]*Parameter, error) {
+	var parameters []*Parameter
+	var firstErr error
+	index := map[string]int{}
+	for _, list := range [][]*Parameter{pathItem.Parameters, op.Parameters} {
+		for _, parameter := range list {
+			if parameter == nil {
+				continue
+			}
+			if parameter.Ref != "" {
+				resolved, err := resolveChain(doc, parameter.Ref, parameter)
+				if err != nil {
+					if firstErr == nil {
+						firstErr = ErrUnresolvedRef{Ref: parameter.Ref, Err: err}
+					}
+					continue
+				}
+				parameter = resolved.(*Parameter)
+			}
+			key := string(parameter.In) + ":" + parameter.Name
+			if i, ok := index[key]; ok {
+				parameters[i] = parameter
+				continue
+			}
+			index[key] = len(parameters)
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters, firstErr
+}
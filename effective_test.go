@@ -0,0 +1,180 @@
+package openapi_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const effectiveSpec = `openapi: 3.0.3
+info:
+  title: effective
+  version: 1.0.0
+servers:
+  - url: https://example.com
+security:
+  - apiKey: []
+paths:
+  /pets/{petId}:
+    servers:
+      - url: https://pets.example.com
+    parameters:
+      - $ref: '#/components/parameters/petId'
+      - name: verbose
+        in: query
+        schema:
+          type: boolean
+    get:
+      parameters:
+        - name: verbose
+          in: query
+          schema:
+            type: integer
+        - name: verbose
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+    delete:
+      servers:
+        - url: https://admin.example.com
+      security:
+        - oauth: [write]
+      responses:
+        '204':
+          description: deleted
+  /health:
+    get:
+      security: []
+      responses:
+        '200':
+          description: ok
+  /shared:
+    $ref: '#/components/pathItems/Shared'
+components:
+  parameters:
+    petId:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: integer
+  pathItems:
+    Shared:
+      get:
+        responses:
+          '200':
+            description: ok
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            write: write pets
+`
+
+func TestDocument_EffectiveParameters(t *testing.T) {
+	doc, err := openapi.Load([]byte(effectiveSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameters, err := doc.EffectiveParameters("/pets/{petId}", http.MethodGet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range parameters {
+		got = append(got, string(p.In)+":"+p.Name+":"+p.Schema.Type)
+	}
+	want := []string{"path:petId:integer", "query:verbose:integer", "header:verbose:string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+
+	candidates := []struct {
+		path     string
+		method   string
+		expected error
+	}{
+		{"/dogs", http.MethodGet, openapi.ErrPathNotFound},
+		{"/health", http.MethodPost, openapi.ErrMethodNotAllowed},
+		{"/shared", http.MethodGet, nil},
+	}
+	for _, c := range candidates {
+		if _, err := doc.EffectiveParameters(c.path, c.method); err != c.expected {
+			t.Errorf("%s %s: %v != %v", c.method, c.path, err, c.expected)
+		}
+	}
+}
+
+func TestDocument_EffectiveServers(t *testing.T) {
+	doc, err := openapi.Load([]byte(effectiveSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		path     string
+		method   string
+		expected string
+	}{
+		{"/pets/{petId}", http.MethodDelete, "https://admin.example.com"},
+		{"/pets/{petId}", http.MethodGet, "https://pets.example.com"},
+		{"/health", http.MethodGet, "https://example.com"},
+	}
+	for _, c := range candidates {
+		servers, err := doc.EffectiveServers(c.path, c.method)
+		if err != nil {
+			t.Errorf("%s %s: %v", c.method, c.path, err)
+			continue
+		}
+		if len(servers) != 1 || servers[0].URL != c.expected {
+			t.Errorf("%s %s: unexpected servers: %+v", c.method, c.path, servers)
+		}
+	}
+}
+
+func TestDocument_EffectiveSecurity(t *testing.T) {
+	doc, err := openapi.Load([]byte(effectiveSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		path     string
+		method   string
+		expected []string
+	}{
+		{"/pets/{petId}", http.MethodDelete, []string{"oauth"}},
+		{"/pets/{petId}", http.MethodGet, []string{"apiKey"}},
+		{"/health", http.MethodGet, nil},
+	}
+	for _, c := range candidates {
+		security, err := doc.EffectiveSecurity(c.path, c.method)
+		if err != nil {
+			t.Errorf("%s %s: %v", c.method, c.path, err)
+			continue
+		}
+		if c.expected == nil {
+			if security == nil || len(security) != 0 {
+				t.Errorf("%s %s: security should be an empty list: %+v", c.method, c.path, security)
+			}
+			continue
+		}
+		var got []string
+		for _, sr := range security {
+			got = append(got, sr.Names()...)
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s %s: %v != %v", c.method, c.path, got, c.expected)
+		}
+	}
+}
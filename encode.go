@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// encoder encodes the object model into a YAML node tree. The keys of
+// the maps are ordered as in the source of doc, if doc is not nil.
+type encoder struct {
+	doc *Document
+}
+
+// encode the value of the object model into a YAML node tree. The keys
+// of the struct fields are same as the ones used when decoding, and
+// the fields with empty value are omitted.
+func (e *encoder) encode(v reflect.Value) (*yaml.Node, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+		}
+	case reflect.Invalid:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	if m, ok := v.Interface().(yaml.Marshaler); ok {
+		x, err := m.MarshalYAML()
+		if err != nil {
+			return nil, err
+		}
+		return e.encode(reflect.ValueOf(x))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.encode(v.Elem())
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Slice:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for i := 0; i < v.Len(); i++ {
+			item, err := e.encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return node, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(v.Interface()); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) (*yaml.Node, error) {
+	if v.Type() == schemaType && !v.FieldByName("Bool").IsNil() {
+		return e.encode(v.FieldByName("Bool").Elem()) // boolean schema
+	}
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	typ := v.Type()
+	fields := structFields(typ)
+	for i := 0; i < typ.NumField(); i++ {
+		key, ok := fieldKey(typ.Field(i))
+		if !ok {
+			key, ok = altFieldKey(typ.Field(i))
+			if !ok {
+				continue
+			}
+			if primary := fields[key].index; primary != nil && !isEmptyValue(v.FieldByIndex(primary)) {
+				continue // the primary field is used
+			}
+		}
+		field := v.Field(i)
+		if isEmptyValue(field) {
+			continue
+		}
+		if key == "" && field.Kind() == reflect.Struct { // inline struct
+			s, err := e.encodeStruct(field)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, s.Content...)
+			continue
+		}
+		if key == "" { // inline
+			m, err := e.encodeMap(field)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, m.Content...)
+			continue
+		}
+		value, err := e.encode(field)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, stringNode(key), value)
+	}
+	return node, nil
+}
+
+// encodeMap encodes the map with its keys in the source order.
+func (e *encoder) encodeMap(v reflect.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	var keys []reflect.Value
+	if e.doc != nil {
+		keys = e.doc.mapKeys(v)
+	} else {
+		keys = mapInfos(nil).mapKeys(v)
+	}
+	for _, k := range keys {
+		key, err := e.encode(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := e.encode(v.MapIndex(k))
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	if e.doc != nil && isExtensibleMap(v.Type()) {
+		ext, err := e.encodeMap(reflect.ValueOf(e.doc.mapInfo(v).extension))
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, ext.Content...)
+	}
+	return node, nil
+}
+
+func stringNode(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// isEmptyValue reports whether the field should be omitted. Empty but
+// non-nil maps and slices are kept, because they have meanings in some
+// places, like empty security requirements.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// writeJSON writes the YAML node tree as JSON.
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	var v interface{}
+	switch node.ShortTag() {
+	case "!!null":
+		v = nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+		v = b
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err != nil {
+			return err
+		}
+		v = i
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("cannot marshal %s into JSON", node.Value)
+		}
+		v = f
+	default:
+		v = node.Value
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
@@ -0,0 +1,158 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var roundTripSpecs = []string{
+	"testdata/testspec.yaml",
+	"testdata/petstore.yaml",
+	"testdata/petstore-expanded.yaml",
+	"testdata/callback-example.yaml",
+	"testdata/link-example.yaml",
+	"testdata/api-with-example.yaml",
+	"testdata/uspto.yaml",
+}
+
+func TestDocument_MarshalYAML(t *testing.T) {
+	for _, filename := range roundTripSpecs {
+		t.Run(filename, func(t *testing.T) {
+			doc, err := openapi.LoadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := yaml.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := openapi.Load(b)
+			if err != nil {
+				t.Fatalf("%s\n%s", err, b)
+			}
+			eqDocument(t, *got, *doc)
+		})
+	}
+}
+
+func TestDocument_MarshalJSON(t *testing.T) {
+	for _, filename := range roundTripSpecs {
+		t.Run(filename, func(t *testing.T) {
+			doc, err := openapi.LoadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !json.Valid(b) {
+				t.Fatalf("invalid JSON: %s", b)
+			}
+			got, err := openapi.Load(b)
+			if err != nil {
+				t.Fatalf("%s\n%s", err, b)
+			}
+			eqDocument(t, *got, *doc)
+		})
+	}
+}
+
+func TestDocument_MarshalKeys(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: marshal
+  version: 1.0.0
+  termsOfService: https://example.com/terms
+paths:
+  /:
+    get:
+      operationId: getRoot
+      security: []
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties:
+                  type: string
+                x-go-type: Root
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"termsOfService:", "operationId: getRoot", "security: []", "additionalProperties:", "x-go-type: Root", `"200":`} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("%q is not found in:\n%s", want, b)
+		}
+	}
+	for _, unwanted := range []string{"components:", "deprecated:", "required:", "null"} {
+		if bytes.Contains(b, []byte(unwanted)) {
+			t.Errorf("empty field %q should be omitted:\n%s", unwanted, b)
+		}
+	}
+}
+
+func TestSecurityRequirement_Marshal(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: marshal
+  version: 1.0.0
+paths: {}
+security:
+  - {}
+  - petstore_auth:
+      - write:pets
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(doc.Security)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `[{},{"petstore_auth":["write:pets"]}]` {
+		t.Errorf("unexpected JSON: %s", b)
+	}
+	y, err := yaml.Marshal(doc.Security)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(y) != "- {}\n- petstore_auth:\n    - write:pets\n" {
+		t.Errorf("unexpected YAML: %s", y)
+	}
+}
+
+func TestDocument_WriteFile(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"openapi.yaml", "openapi.json"} {
+		filename := filepath.Join(dir, name)
+		if err := doc.WriteFile(filename); err != nil {
+			t.Fatal(err)
+		}
+		got, err := openapi.LoadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		eqDocument(t, *got, *doc)
+		if pos, _ := got.Position("/info"); !strings.HasSuffix(pos.File, name) {
+			t.Errorf("unexpected position: %s", pos)
+		}
+	}
+}
@@ -7,16 +7,28 @@ type Encoding struct {
 	ContentType   string `yaml:"contentType"`
 	Headers       map[string]*Header
 	Style         string
-	Explode       bool
+	Explode       *bool
 	AllowReserved bool `yaml:"allowReserved"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Encoding object.
 func (encoding Encoding) Validate() error {
-	for _, header := range encoding.Headers {
-		if err := header.Validate(); err != nil {
-			return err
+	return validateFirst(encoding)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (encoding Encoding) GetExtension(name string, v interface{}) error {
+	return getExtension(encoding.Extension, name, v)
+}
+
+func (encoding Encoding) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, encoding.Extension)
+	for name, header := range encoding.Headers {
+		if header != nil {
+			header.validate(v, joinPointer(ptr, "headers", name))
 		}
 	}
-	return nil
 }
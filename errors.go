@@ -20,9 +20,10 @@ func (fe ErrFormatInvalid) Error() string {
 
 // central error variables relating format
 var (
-	ErrMapKeyFormat      = ErrFormatInvalid{Target: "map key"}
-	ErrPathFormat        = ErrFormatInvalid{Target: "path"}
-	ErrRuntimeExprFormat = ErrFormatInvalid{Target: "key", Format: "RuntimeExpression"}
+	ErrMapKeyFormat       = ErrFormatInvalid{Target: "map key"}
+	ErrPathFormat         = ErrFormatInvalid{Target: "path"}
+	ErrPathTemplateFormat = ErrFormatInvalid{Target: "path template"}
+	ErrRuntimeExprFormat  = ErrFormatInvalid{Target: "key", Format: "RuntimeExpression"}
 )
 
 // ErrRequired is returned when missing some required parameter
@@ -44,6 +45,15 @@ const (
 	// ErrUnsupportedVersion is returned when the openapi version
 	// is unsupported by this package.
 	ErrUnsupportedVersion errString = "the OAS version is not supported"
+	// ErrPathNotFound is returned when no path in the document
+	// matches the request.
+	ErrPathNotFound errString = "no path matches the request"
+	// ErrMethodNotAllowed is returned when the path matching the
+	// request has no operation for the method.
+	ErrMethodNotAllowed errString = "the method is not allowed for the path"
+	// ErrRequestBodyTooLarge is returned when the request body is
+	// larger than the limit.
+	ErrRequestBodyTooLarge errString = "the request body is too large"
 	// ErrInvalidFlowType is returned when the OAuth flow type is invalid
 	// or not set to the object.
 	ErrInvalidFlowType errString = "invalid flow type"
@@ -56,6 +66,9 @@ const (
 	// ErrInvalidStatusCode is returned when specified status code is not
 	// valid as HTTP status code.
 	ErrInvalidStatusCode errString = "status code is invalid"
+	// ErrExampleValueExclusive is returned when the example object has
+	// both value and externalValue.
+	ErrExampleValueExclusive errString = "value and externalValue are mutually exclusive"
 	// ErrMissingRootDocument is returned when validating securityRequirement
 	// object but root document is not set.
 	ErrMissingRootDocument errString = "missing root document for security requirement"
@@ -107,6 +120,38 @@ func (snde ErrNotDeclared) Error() string {
 	return fmt.Sprintf("%s is not declared in components.securitySchemes", snde.Name)
 }
 
+// ErrScopeNotDeclared is returned when the scope in the security
+// requirement is declared in none of the flows of the OAuth2 security
+// scheme. Flows are the names of the flows which are checked.
+type ErrScopeNotDeclared struct {
+	Scope string
+	Flows []string
+}
+
+func (snde ErrScopeNotDeclared) Error() string {
+	return fmt.Sprintf("scope %s is not declared in any of the flows: %s", snde.Scope, strings.Join(snde.Flows, ", "))
+}
+
+// ErrPathParameterNotDeclared is returned when the template variable
+// in the path is not declared as a path parameter of the operation.
+type ErrPathParameterNotDeclared struct {
+	Name string
+}
+
+func (pnde ErrPathParameterNotDeclared) Error() string {
+	return fmt.Sprintf("path template variable {%s} is not declared as a path parameter", pnde.Name)
+}
+
+// ErrPathParameterNotInTemplate is returned when the path parameter
+// is declared but the path template does not have it.
+type ErrPathParameterNotInTemplate struct {
+	Name string
+}
+
+func (pnite ErrPathParameterNotInTemplate) Error() string {
+	return fmt.Sprintf("path parameter %s is not in the path template", pnite.Name)
+}
+
 // ErrMustEmpty returned when the securityRequirement is not
 // empty but must be empty.
 type ErrMustEmpty struct {
@@ -126,3 +171,117 @@ type ErrMustOneOf struct {
 func (ooe ErrMustOneOf) Error() string {
 	return fmt.Sprintf("%s must be one of: %s", ooe.Object, strings.Join(ooe.ValidValues, ", "))
 }
+
+// LocatedError is an error found at the specific location in a document.
+// Pointer is a JSON pointer (RFC 6901) to the object where the error is found,
+// and Position is its position in the source if known.
+type LocatedError struct {
+	Pointer  string
+	Position Position
+	Err      error
+}
+
+func (le LocatedError) Error() string {
+	var prefix string
+	if le.Position.IsValid() {
+		prefix = le.Position.String() + ": "
+	}
+	if le.Pointer == "" {
+		return prefix + le.Err.Error()
+	}
+	return fmt.Sprintf("%s%s: %s", prefix, le.Pointer, le.Err)
+}
+
+// Unwrap returns the underlying error.
+func (le LocatedError) Unwrap() error {
+	return le.Err
+}
+
+// ErrorList is a list of errors found in a document.
+type ErrorList []LocatedError
+
+func (el ErrorList) Error() string {
+	msgs := make([]string, len(el))
+	for i, err := range el {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// ErrUnresolvedRef is returned when the reference cannot be resolved.
+type ErrUnresolvedRef struct {
+	Ref string
+	Err error
+}
+
+func (ure ErrUnresolvedRef) Error() string {
+	return fmt.Sprintf("cannot resolve %s: %s", ure.Ref, ure.Err)
+}
+
+// Unwrap returns the underlying error.
+func (ure ErrUnresolvedRef) Unwrap() error {
+	return ure.Err
+}
+
+// ErrUnknownField is returned when the object has a field which is
+// neither defined in the specification nor a specification extension.
+type ErrUnknownField struct {
+	Name string
+}
+
+func (ufe ErrUnknownField) Error() string {
+	return fmt.Sprintf("unknown field: %s", ufe.Name)
+}
+
+// ErrUnsupportedField is returned when the field is not supported in
+// the OAS version of the document.
+type ErrUnsupportedField struct {
+	Name    string
+	Version string
+}
+
+func (ufe ErrUnsupportedField) Error() string {
+	return fmt.Sprintf("%s is not supported in OpenAPI %s", ufe.Name, ufe.Version)
+}
+
+// ErrValue is returned when a value does not satisfy the keyword of
+// its schema.
+type ErrValue struct {
+	Keyword string
+	Reason  string
+}
+
+func (ve ErrValue) Error() string {
+	return fmt.Sprintf("%s: %s", ve.Keyword, ve.Reason)
+}
+
+// ErrSwaggerIncompatible is returned as a warning when the feature of
+// the document cannot be represented in Swagger 2.0.
+type ErrSwaggerIncompatible struct {
+	Name string
+}
+
+func (sie ErrSwaggerIncompatible) Error() string {
+	return fmt.Sprintf("%s cannot be represented in Swagger 2.0", sie.Name)
+}
+
+// ErrReferenceOutOfRoot is returned when the reference points to a
+// file out of the root of the file system which the documents are
+// loaded from.
+type ErrReferenceOutOfRoot struct {
+	Ref string
+}
+
+func (roore ErrReferenceOutOfRoot) Error() string {
+	return fmt.Sprintf("reference %s is out of the root of the file system", roore.Ref)
+}
+
+// ErrExtensionNotFound is returned when the object does not have the
+// specification extension.
+type ErrExtensionNotFound struct {
+	Name string
+}
+
+func (enfe ErrExtensionNotFound) Error() string {
+	return fmt.Sprintf("specification extension is not found: %s", enfe.Name)
+}
@@ -10,4 +10,72 @@ type Example struct {
 	ExternalValue interface{} `yaml:"externalValue"`
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+// Validate the values of Example object.
+func (example Example) Validate() error {
+	return validateFirst(example)
+}
+
+func (example Example) validate(v *validator, ptr string) {
+	if example.Ref != "" {
+		return // validated in doc.Components
+	}
+	validateExtension(v, ptr, example.Extension)
+	if example.Value != nil && example.ExternalValue != nil {
+		v.report(ptr, ErrExampleValueExclusive)
+	}
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (example Example) GetExtension(name string, v interface{}) error {
+	return getExtension(example.Extension, name, v)
+}
+
+// validateExample validates the example value against the schema. The
+// violations are reported with the pointers into the value under ptr.
+func (v *validator) validateExample(schema *Schema, value interface{}, ptr string) {
+	if v.doc == nil || schema == nil || value == nil {
+		return
+	}
+	err := schema.validateValue(v.doc, value, anyContext)
+	errs, ok := err.(ErrorList)
+	if !ok {
+		if err != nil {
+			v.report(ptr, err)
+		}
+		return
+	}
+	for _, e := range errs {
+		v.report(ptr+e.Pointer, e.Err)
+	}
+}
+
+// validateExamples validates the values of the examples against the
+// schema. The referenced examples are resolved, and their violations
+// are reported at the reference.
+func (v *validator) validateExamples(schema *Schema, examples map[string]*Example, ptr string) {
+	if v.doc == nil || schema == nil {
+		return
+	}
+	for name, example := range examples {
+		if example == nil {
+			continue
+		}
+		examplePtr := joinPointer(ptr, name)
+		if example.Ref != "" {
+			resolved, err := resolveChain(v.doc, example.Ref, example)
+			if err != nil {
+				v.report(examplePtr, ErrUnresolvedRef{Ref: example.Ref, Err: err})
+				continue
+			}
+			example = resolved.(*Example)
+		} else {
+			examplePtr = joinPointer(examplePtr, "value")
+		}
+		v.validateExample(schema, example.Value, examplePtr)
+	}
 }
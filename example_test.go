@@ -0,0 +1,108 @@
+package openapi_test
+
+import (
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestExample_Validate(t *testing.T) {
+	candidates := []candidate{
+		{"empty", openapi.Example{}, nil},
+		{"value", openapi.Example{Value: "foo"}, nil},
+		{"externalValue", openapi.Example{ExternalValue: "https://example.com/foo.json"}, nil},
+		{"both", openapi.Example{Value: "foo", ExternalValue: "https://example.com/foo.json"}, openapi.ErrExampleValueExclusive},
+	}
+	testValidater(t, candidates)
+}
+
+const exampleSpec = `openapi: 3.0.3
+info:
+  title: example
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+          example: 1000
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [name, age]
+          examples:
+            valid:
+              value: name
+            invalid:
+              value: weight
+      responses:
+        '200':
+          description: ok
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+              example: many
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example:
+                name: kitty
+                age: old
+              examples:
+                ref:
+                  $ref: '#/components/examples/Bird'
+                both:
+                  value:
+                    name: pochi
+                  externalValue: https://example.com/pochi.json
+components:
+  examples:
+    Bird:
+      value:
+        age: 1
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          example: 1
+        age:
+          type: integer
+          default: -1
+          minimum: 0
+      example:
+        name: tama
+        age: 2
+`
+
+func TestDocument_ValidateAll_Examples(t *testing.T) {
+	doc, err := openapi.Load([]byte(exampleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateAll()
+	want := []string{
+		"/components/schemas/Pet/properties/age/default",
+		"/components/schemas/Pet/properties/name/example",
+		"/paths/~1pets/get/parameters/0/example",
+		"/paths/~1pets/get/parameters/1/examples/invalid/value",
+		"/paths/~1pets/get/responses/200/content/application~1json/example/age",
+		"/paths/~1pets/get/responses/200/content/application~1json/examples/both",
+		"/paths/~1pets/get/responses/200/content/application~1json/examples/ref",
+		"/paths/~1pets/get/responses/200/headers/X-Rate-Limit/example",
+	}
+	if got := errorPointers(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected errors:\n  got:\t%v\n  want:\t%v", got, want)
+	}
+}
@@ -5,9 +5,8 @@ type Validater = validater
 var (
 	ValidateComponentKeys  = validateComponentKeys
 	ReduceComponentKeys    = reduceComponentKeys
-	ReduceComponentObjects = reduceComponentObjects
 	HasDuplicatedParameter = hasDuplicatedParameter
 	ValidateStatusCode     = validateStatusCode
 	MustURL                = mustURL
-	ValidateAll            = validateAll
+	JoinPointer            = joinPointer
 )
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// extensionPrefix is the prefix of the keys of specification extensions.
+const extensionPrefix = "x-"
+
+// extensibleMapTypes are the map objects which may be extended with
+// specification extensions. As the extensions cannot be held as an
+// entry of the map, they are held by the document, and set with
+// Document.SetMapExtension.
+var extensibleMapTypes = map[reflect.Type]struct{}{
+	reflect.TypeOf(Paths(nil)):     {},
+	reflect.TypeOf(Responses(nil)): {},
+	reflect.TypeOf(Callback(nil)):  {},
+
+	reflect.TypeOf(swaggerPaths(nil)):     {},
+	reflect.TypeOf(swaggerResponses(nil)): {},
+}
+
+func isExtensibleMap(typ reflect.Type) bool {
+	_, ok := extensibleMapTypes[typ]
+	return ok
+}
+
+// getExtension stores the value of the specification extension name
+// in ext into the value pointed by v. If the value cannot be assigned
+// to v directly, it is converted through YAML, so v can be a pointer
+// to a struct or a typed map.
+func getExtension(ext map[string]interface{}, name string, v interface{}) error {
+	value, ok := ext[name]
+	if !ok {
+		return ErrExtensionNotFound{Name: name}
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrTypeAssertion
+	}
+	if value != nil && reflect.TypeOf(value).AssignableTo(rv.Elem().Type()) {
+		rv.Elem().Set(reflect.ValueOf(value))
+		return nil
+	}
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(b, v)
+}
+
+// validateExtension reports the keys in ext which are not specification
+// extensions. ext holds the keys which are not known fields of the
+// object.
+func validateExtension(v *validator, ptr string, ext map[string]interface{}) {
+	for k := range ext {
+		if !strings.HasPrefix(k, extensionPrefix) {
+			v.report(joinPointer(ptr, k), ErrUnknownField{Name: k})
+		}
+	}
+}
+
+// MapExtension returns the specification extensions of the map object
+// in the document, i.e. Paths, Responses or Callback, which cannot hold
+// them by themselves.
+func (doc *Document) MapExtension(m interface{}) map[string]interface{} {
+	rv := reflect.ValueOf(m)
+	if !rv.IsValid() || !isExtensibleMap(rv.Type()) || rv.IsNil() {
+		return nil
+	}
+	return doc.mapInfo(rv).extension
+}
+
+// GetMapExtension stores the value of the specification extension
+// name of the map object in the document into the value pointed by v.
+// See MapExtension for the map objects.
+func (doc *Document) GetMapExtension(m interface{}, name string, v interface{}) error {
+	return getExtension(doc.MapExtension(m), name, v)
+}
+
+// SetMapExtension sets the specification extension name of the map
+// object in the document to value. See MapExtension for the map
+// objects. If m is not one of them or it is nil, ErrTypeAssertion is
+// returned, and if name does not start with "x-", ErrUnknownField is
+// returned.
+func (doc *Document) SetMapExtension(m interface{}, name string, value interface{}) error {
+	rv := reflect.ValueOf(m)
+	if !rv.IsValid() || !isExtensibleMap(rv.Type()) || rv.IsNil() {
+		return ErrTypeAssertion
+	}
+	if !strings.HasPrefix(name, extensionPrefix) {
+		return ErrUnknownField{Name: name}
+	}
+	doc.updateMapInfo(rv, func(info *mapInfo) {
+		info.setExtension(name, value)
+	})
+	return nil
+}
@@ -0,0 +1,200 @@
+package openapi_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const extendedSpec = `openapi: 3.0.0
+x-root: true
+info:
+  title: extended
+  version: 1.0.0
+  x-logo:
+    url: https://example.com/logo.png
+    altText: logo
+paths:
+  x-paths: paths
+  /pets:
+    x-internal: true
+    post:
+      x-codegen-request-body-name: body
+      x-amazon-apigateway-integration:
+        type: http
+        httpMethod: POST
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+          x-example-limit: 10
+      responses:
+        x-responses: 1
+        '200':
+          description: ok
+          x-response: ok
+`
+
+func TestGetExtension(t *testing.T) {
+	doc, err := openapi.Load([]byte(extendedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ValidateAll(); err != nil {
+		t.Fatal(err)
+	}
+	var root bool
+	if err := doc.GetExtension("x-root", &root); err != nil || !root {
+		t.Errorf("x-root: %v, %v", root, err)
+	}
+	var logo struct {
+		URL     string
+		AltText string `yaml:"altText"`
+	}
+	if err := doc.Info.GetExtension("x-logo", &logo); err != nil || logo.AltText != "logo" {
+		t.Errorf("x-logo: %+v, %v", logo, err)
+	}
+	pathItem := doc.Paths["/pets"]
+	if len(doc.Paths) != 1 {
+		t.Errorf("extension of paths should not be a path: %v", doc.PathKeys())
+	}
+	var internal bool
+	if err := pathItem.GetExtension("x-internal", &internal); err != nil || !internal {
+		t.Errorf("x-internal: %v, %v", internal, err)
+	}
+	var name string
+	if err := pathItem.Post.GetExtension("x-codegen-request-body-name", &name); err != nil || name != "body" {
+		t.Errorf("x-codegen-request-body-name: %s, %v", name, err)
+	}
+	var integration map[string]string
+	if err := pathItem.Post.GetExtension("x-amazon-apigateway-integration", &integration); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"type": "http", "httpMethod": "POST"}; !reflect.DeepEqual(integration, want) {
+		t.Errorf("%v != %v", integration, want)
+	}
+	var limit int
+	if err := pathItem.Post.Parameters[0].GetExtension("x-example-limit", &limit); err != nil || limit != 10 {
+		t.Errorf("x-example-limit: %d, %v", limit, err)
+	}
+	var paths string
+	if err := doc.GetMapExtension(doc.Paths, "x-paths", &paths); err != nil || paths != "paths" {
+		t.Errorf("x-paths: %s, %v", paths, err)
+	}
+	responses := pathItem.Post.Responses
+	if got := doc.MapExtension(responses); !reflect.DeepEqual(got, map[string]interface{}{"x-responses": 1}) {
+		t.Errorf("unexpected extension of responses: %v", got)
+	}
+	if _, ok := responses["x-responses"]; ok {
+		t.Error("extension of responses should not be a response")
+	}
+
+	var notFound openapi.ErrExtensionNotFound
+	if err := doc.GetExtension("x-unknown", &root); !errors.As(err, &notFound) {
+		t.Errorf("error should be ErrExtensionNotFound: %v", err)
+	}
+}
+
+func TestExtension_Marshal(t *testing.T) {
+	doc, err := openapi.Load([]byte(extendedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := openapi.Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eqDocument(t, *got, *doc)
+	if !reflect.DeepEqual(got.MapExtension(got.Paths), doc.MapExtension(doc.Paths)) {
+		t.Errorf("extension of paths is lost:\n%s", b)
+	}
+	responses := got.Paths["/pets"].Post.Responses
+	if got.MapExtension(responses)["x-responses"] != 1 {
+		t.Errorf("extension of responses is lost:\n%s", b)
+	}
+}
+
+func TestDocument_SetMapExtension(t *testing.T) {
+	doc, err := openapi.Load([]byte(extendedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.SetMapExtension(doc.Paths, "x-paths", "updated"); err != nil {
+		t.Fatal(err)
+	}
+	callback := openapi.Callback{"{$request.body#/url}": &openapi.PathItem{}}
+	doc.Paths["/pets"].Post.Callbacks = map[string]*openapi.Callback{"onEvent": &callback}
+	if err := doc.SetMapExtension(callback, "x-callback", true); err != nil {
+		t.Fatal(err)
+	}
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := openapi.Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths string
+	if err := got.GetMapExtension(got.Paths, "x-paths", &paths); err != nil || paths != "updated" {
+		t.Errorf("x-paths: %s, %v\n%s", paths, err, b)
+	}
+	gotCallback := *got.Paths["/pets"].Post.Callbacks["onEvent"]
+	if got.MapExtension(gotCallback)["x-callback"] != true {
+		t.Errorf("extension of callback is lost:\n%s", b)
+	}
+
+	if err := doc.SetMapExtension(doc.Paths, "paths", 1); err == nil {
+		t.Error("error should be returned for the name without x-")
+	}
+	if err := doc.SetMapExtension(doc.Components, "x-components", 1); err != openapi.ErrTypeAssertion {
+		t.Errorf("error should be ErrTypeAssertion: %v", err)
+	}
+}
+
+func TestExtension_Validate(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: extended
+  version: 1.0.0
+  logo: https://example.com/logo.png
+paths:
+  /pets:
+    get:
+      operationid: listPets
+      responses:
+        '200':
+          description: ok
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateAll()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList: %v", err)
+	}
+	want := []openapi.LocatedError{
+		{Pointer: "/info/logo", Err: openapi.ErrUnknownField{Name: "logo"}},
+		{Pointer: "/paths/~1pets/get/operationid", Err: openapi.ErrUnknownField{Name: "operationid"}},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for i := range want {
+		if errs[i].Pointer != want[i].Pointer || errs[i].Err != want[i].Err {
+			t.Errorf("%v != %v", errs[i], want[i])
+		}
+		if !errs[i].Position.IsValid() {
+			t.Errorf("position should be set: %v", errs[i])
+		}
+	}
+}
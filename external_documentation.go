@@ -6,9 +6,24 @@ package openapi
 type ExternalDocumentation struct {
 	Description string
 	URL         string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of ExternalDocumentaion object.
 func (externalDocumentation ExternalDocumentation) Validate() error {
-	return mustURL("externalDocumentation.url", externalDocumentation.URL)
+	return validateFirst(externalDocumentation)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (externalDocumentation ExternalDocumentation) GetExtension(name string, v interface{}) error {
+	return getExtension(externalDocumentation.Extension, name, v)
+}
+
+func (externalDocumentation ExternalDocumentation) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, externalDocumentation.Extension)
+	if err := mustURL("externalDocumentation.url", externalDocumentation.URL); err != nil {
+		v.report(joinPointer(ptr, "url"), err)
+	}
 }
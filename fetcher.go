@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Fetcher fetches remote documents referenced by http(s) URLs.
+type Fetcher interface {
+	Fetch(u *url.URL) ([]byte, error)
+}
+
+// FetcherFunc is an adapter to allow the use of ordinary functions
+// as Fetcher.
+type FetcherFunc func(u *url.URL) ([]byte, error)
+
+// Fetch calls f(u).
+func (f FetcherFunc) Fetch(u *url.URL) ([]byte, error) {
+	return f(u)
+}
+
+// HTTPFetcher is a Fetcher which fetches documents with HTTP GET.
+// If Client is nil, http.DefaultClient is used.
+type HTTPFetcher struct {
+	Client *http.Client
+}
+
+// Fetch the document at u.
+func (f HTTPFetcher) Fetch(u *url.URL) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
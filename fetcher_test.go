@@ -0,0 +1,118 @@
+package openapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/fstest"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func newSpecServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoadURL(t *testing.T) {
+	srv := newSpecServer(t, map[string]string{
+		"/api/openapi.yaml": `openapi: 3.0.0
+info:
+  title: remote
+  version: 1.0.0
+paths: {}
+`,
+		"/api/schemas/pet.yaml": `Pet:
+  type: object
+  properties:
+    id:
+      $ref: 'common.yaml#/Id'
+`,
+		"/api/schemas/common.yaml": `Id:
+  type: integer
+`,
+	})
+	doc, err := openapi.LoadURL(srv.URL + "/api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Info.Title != "remote" {
+		t.Errorf("%s != remote", doc.Info.Title)
+	}
+	pet, err := openapi.ResolveSchema(doc, "schemas/pet.yaml#/Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	idRef := pet.Properties["id"].Ref
+	if want := srv.URL + "/api/schemas/common.yaml#/Id"; idRef != want {
+		t.Errorf("%s != %s", idRef, want)
+	}
+	id, err := openapi.ResolveSchema(doc, idRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Type != "integer" {
+		t.Errorf("%s != integer", id.Type)
+	}
+	if _, err := openapi.ResolveSchema(doc, "schemas/unknown.yaml"); err == nil {
+		t.Error("error should be occurred for not found document")
+	}
+}
+
+func TestLoader_Fetcher(t *testing.T) {
+	var fetched []string
+	fetcher := openapi.FetcherFunc(func(u *url.URL) ([]byte, error) {
+		fetched = append(fetched, u.String())
+		switch u.String() {
+		case "https://example.com/schemas.yaml":
+			return []byte(`Pet:
+  type: object
+Error:
+  type: string
+`), nil
+		}
+		return nil, errors.New("not found")
+	})
+	loader := &openapi.Loader{
+		FS: fstest.MapFS{
+			"openapi.yaml": &fstest.MapFile{Data: []byte(`openapi: 3.0.0
+info:
+  title: foo
+  version: 1.0.0
+paths: {}
+`)},
+		},
+		Fetcher: fetcher,
+	}
+	doc, err := loader.Load("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet, err := openapi.ResolveSchema(doc, "https://example.com/schemas.yaml#/Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pet.Type != "object" {
+		t.Errorf("%s != object", pet.Type)
+	}
+	if _, err := openapi.ResolveSchema(doc, "https://example.com/schemas.yaml#/Error"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fetched) != 1 {
+		t.Errorf("the fetched document should be cached: %v", fetched)
+	}
+	if _, err := openapi.ResolveSchema(doc, "ftp://example.com/schemas.yaml#/Pet"); err == nil {
+		t.Error("error should be occurred for unsupported scheme")
+	}
+}
@@ -0,0 +1,298 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format checks the values of a schema format, like date-time or
+// uuid, and gives an example of them.
+type Format struct {
+	// Check returns an error if the value is not valid in the format.
+	// The value is the one decoded from JSON or from a parameter, and
+	// Check should accept the values of the types which the format
+	// does not apply to. If Check is nil, any value is valid.
+	Check func(value interface{}) error
+	// Example is a valid value in the format, which can be used as
+	// a mock data.
+	Example interface{}
+}
+
+// StringFormat returns the Check function of a string format. The
+// values other than strings are not checked.
+func StringFormat(valid func(s string) bool) func(value interface{}) error {
+	return func(value interface{}) error {
+		s, ok := value.(string)
+		if !ok || valid(s) {
+			return nil
+		}
+		return fmt.Errorf("%q is not valid", s)
+	}
+}
+
+// UnknownFormatPolicy decides how the formats which are not registered
+// are treated on validating values.
+type UnknownFormatPolicy int
+
+// UnknownFormatPolicy list.
+const (
+	// IgnoreUnknownFormat accepts any value for the unknown formats.
+	IgnoreUnknownFormat UnknownFormatPolicy = iota
+	// WarnUnknownFormat accepts any value for the unknown formats,
+	// but warns once for each of them.
+	WarnUnknownFormat
+	// FailUnknownFormat reports the values of the unknown formats as
+	// invalid.
+	FailUnknownFormat
+)
+
+// FormatRegistry holds the formats by their names.
+type FormatRegistry struct {
+	// UnknownFormat is the policy for the formats which are not
+	// registered.
+	UnknownFormat UnknownFormatPolicy
+	// Warn is called with the name of the unknown format when
+	// UnknownFormat is WarnUnknownFormat. If Warn is nil, the warning
+	// is written to the standard logger.
+	Warn func(format string)
+
+	mu      sync.RWMutex
+	formats map[string]Format
+	warned  sync.Map
+}
+
+// NewFormatRegistry returns a new registry which has the built-in
+// formats: date, date-time, time, email, uuid, uri, hostname, ipv4,
+// ipv6, byte, binary, password, int32, int64, float and double.
+func NewFormatRegistry() *FormatRegistry {
+	r := &FormatRegistry{formats: map[string]Format{}}
+	for name, format := range builtinFormats {
+		r.formats[name] = format
+	}
+	return r
+}
+
+// DefaultFormats is the registry used by the documents which are not
+// given any registry with LoadOptions.Formats.
+var DefaultFormats = NewFormatRegistry()
+
+// RegisterFormat registers the format to DefaultFormats.
+func RegisterFormat(name string, format Format) {
+	DefaultFormats.Register(name, format)
+}
+
+// Register registers the format with the name. The format registered
+// with the same name before is replaced.
+func (r *FormatRegistry) Register(name string, format Format) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.formats[name] = format
+}
+
+// Lookup returns the format registered with the name.
+func (r *FormatRegistry) Lookup(name string) (Format, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	format, ok := r.formats[name]
+	return format, ok
+}
+
+// Example returns the example value of the format. ok is false if the
+// format is not registered or it has no example.
+func (r *FormatRegistry) Example(name string) (example interface{}, ok bool) {
+	format, ok := r.Lookup(name)
+	if !ok || format.Example == nil {
+		return nil, false
+	}
+	return format.Example, true
+}
+
+// Check checks the value in the format. The empty format accepts any
+// value, and the unknown formats are treated by UnknownFormat.
+func (r *FormatRegistry) Check(name string, value interface{}) error {
+	if name == "" {
+		return nil
+	}
+	format, ok := r.Lookup(name)
+	if !ok {
+		switch r.UnknownFormat {
+		case WarnUnknownFormat:
+			if _, warned := r.warned.LoadOrStore(name, struct{}{}); !warned {
+				r.warn(name)
+			}
+		case FailUnknownFormat:
+			return fmt.Errorf("unknown format %q", name)
+		}
+		return nil
+	}
+	if format.Check == nil {
+		return nil
+	}
+	return format.Check(value)
+}
+
+func (r *FormatRegistry) warn(name string) {
+	if r.Warn != nil {
+		r.Warn(name)
+		return
+	}
+	log.Printf("openapi: unknown format %q", name)
+}
+
+// formatRegistry returns the registry which the document uses.
+func (doc *Document) formatRegistry() *FormatRegistry {
+	if doc == nil || doc.formats == nil {
+		return DefaultFormats
+	}
+	return doc.formats
+}
+
+var (
+	uuidRegexp     = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+	hostnameRegexp = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+var builtinFormats = map[string]Format{
+	"date": {
+		Check:   StringFormat(timeLayout("2006-01-02")),
+		Example: "2017-07-21",
+	},
+	"date-time": {
+		Check:   StringFormat(timeLayout(time.RFC3339Nano)),
+		Example: "2017-07-21T17:32:28Z",
+	},
+	"time": {
+		Check:   StringFormat(timeLayout("15:04:05.999999999Z07:00")),
+		Example: "17:32:28Z",
+	},
+	"email": {
+		Check:   StringFormat(emailRegexp.MatchString),
+		Example: "user@example.com",
+	},
+	"uuid": {
+		Check:   StringFormat(uuidRegexp.MatchString),
+		Example: "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	},
+	"uri": {
+		Check:   StringFormat(isAbsoluteURI),
+		Example: "https://example.com/",
+	},
+	"hostname": {
+		Check:   StringFormat(isHostname),
+		Example: "example.com",
+	},
+	"ipv4": {
+		Check:   StringFormat(isIPv4),
+		Example: "192.0.2.1",
+	},
+	"ipv6": {
+		Check:   StringFormat(isIPv6),
+		Example: "2001:db8::1",
+	},
+	"byte": {
+		Check:   StringFormat(isBase64),
+		Example: "U3dhZ2dlciByb2Nrcw==",
+	},
+	"binary":   {},
+	"password": {Example: "password"},
+	"int32": {
+		Check:   integerRange(math.MinInt32, math.MaxInt32),
+		Example: int64(0),
+	},
+	"int64": {
+		Check:   integerRange(math.MinInt64, math.MaxInt64),
+		Example: int64(0),
+	},
+	"float": {
+		Check:   numberRange(math.MaxFloat32),
+		Example: 0.0,
+	},
+	"double": {
+		Check:   numberRange(math.MaxFloat64),
+		Example: 0.0,
+	},
+}
+
+func timeLayout(layout string) func(string) bool {
+	return func(s string) bool {
+		_, err := time.Parse(layout, s)
+		return err == nil
+	}
+}
+
+func isAbsoluteURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+func isHostname(s string) bool {
+	return len(s) <= 253 && hostnameRegexp.MatchString(s)
+}
+
+func isIPv4(s string) bool {
+	return !strings.Contains(s, ":") && net.ParseIP(s) != nil
+}
+
+func isIPv6(s string) bool {
+	return strings.Contains(s, ":") && net.ParseIP(s) != nil
+}
+
+func isBase64(s string) bool {
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+// integerRange returns the Check function of an integer format, which
+// checks the value is in [min, max].
+func integerRange(min, max int64) func(value interface{}) error {
+	return func(value interface{}) error {
+		var ok bool
+		if n, isNumber := value.(json.Number); isNumber {
+			i, err := strconv.ParseInt(n.String(), 10, 64)
+			if err == nil {
+				value = i
+			} else if numErr, isNumErr := err.(*strconv.NumError); isNumErr && numErr.Err == strconv.ErrRange {
+				return fmt.Errorf("%v is out of range", value)
+			}
+		}
+		if i, isInt := value.(int64); isInt {
+			ok = min <= i && i <= max
+		} else {
+			f, isNumber := toFloat(value)
+			if !isNumber {
+				return nil
+			}
+			ok = float64(min) <= f && f <= float64(max)
+		}
+		if !ok {
+			return fmt.Errorf("%v is out of range", value)
+		}
+		return nil
+	}
+}
+
+// numberRange returns the Check function of a number format, which
+// checks the value is finite and its absolute value is not greater
+// than max.
+func numberRange(max float64) func(value interface{}) error {
+	return func(value interface{}) error {
+		f, ok := toFloat(value)
+		if !ok {
+			return nil
+		}
+		if math.IsNaN(f) || math.Abs(f) > max {
+			return fmt.Errorf("%v is out of range", value)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestFormatRegistry_Check(t *testing.T) {
+	formats := openapi.NewFormatRegistry()
+	candidates := []struct {
+		format string
+		value  interface{}
+		valid  bool
+	}{
+		{"date", "2017-07-21", true},
+		{"date", "2017-07-32", false},
+		{"date-time", "2017-07-21T17:32:28Z", true},
+		{"date-time", "2017-07-21T17:32:28.123+09:00", true},
+		{"date-time", "2017-07-21 17:32:28", false},
+		{"time", "17:32:28Z", true},
+		{"time", "17:32:28", false},
+		{"email", "user@example.com", true},
+		{"email", "user", false},
+		{"uuid", "3fa85f64-5717-4562-b3fc-2c963f66afa6", true},
+		{"uuid", "3fa85f64", false},
+		{"uri", "https://example.com/path", true},
+		{"uri", "/path", false},
+		{"hostname", "api.example.com", true},
+		{"hostname", "-example.com", false},
+		{"ipv4", "192.0.2.1", true},
+		{"ipv4", "2001:db8::1", false},
+		{"ipv6", "2001:db8::1", true},
+		{"ipv6", "192.0.2.1", false},
+		{"byte", "U3dhZ2dlciByb2Nrcw==", true},
+		{"byte", "not base64!", false},
+		{"int32", json.Number("2147483647"), true},
+		{"int32", json.Number("2147483648"), false},
+		{"int32", int64(-2147483649), false},
+		{"int64", json.Number("9223372036854775807"), true},
+		{"int64", json.Number("9223372036854775808"), false},
+		{"float", 3.4e38, true},
+		{"float", 3.5e38, false},
+		{"double", 3.5e38, true},
+		{"password", "secret", true},
+		{"date", 1, true},    // the format does not apply to numbers
+		{"int32", "a", true}, // nor to strings
+		{"unknown", "any", true},
+		{"", "any", true},
+	}
+	for _, c := range candidates {
+		err := formats.Check(c.format, c.value)
+		if (err == nil) != c.valid {
+			t.Errorf("%s %v: unexpected result: %v", c.format, c.value, err)
+		}
+	}
+}
+
+func TestFormatRegistry_UnknownFormat(t *testing.T) {
+	formats := openapi.NewFormatRegistry()
+	var warned []string
+	formats.Warn = func(format string) { warned = append(warned, format) }
+
+	formats.UnknownFormat = openapi.WarnUnknownFormat
+	for i := 0; i < 2; i++ {
+		if err := formats.Check("x-unknown", "a"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if !reflect.DeepEqual(warned, []string{"x-unknown"}) {
+		t.Errorf("unexpected warnings: %v", warned)
+	}
+
+	formats.UnknownFormat = openapi.FailUnknownFormat
+	if err := formats.Check("x-unknown", "a"); err == nil {
+		t.Error("error should be returned")
+	}
+}
+
+const formatSpec = `openapi: 3.0.3
+info:
+  title: format
+  version: 1.0.0
+paths:
+  /items/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: x-ulid
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        id:
+          type: string
+          format: x-ulid
+        createdAt:
+          type: string
+          format: date-time
+        size:
+          type: integer
+          format: int32
+`
+
+func TestFormat_Custom(t *testing.T) {
+	ulid := regexp.MustCompile("^[0-9A-HJKMNP-TV-Z]{26}$")
+	formats := openapi.NewFormatRegistry()
+	formats.Register("x-ulid", openapi.Format{
+		Check:   openapi.StringFormat(ulid.MatchString),
+		Example: "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+	})
+	doc, err := openapi.LoadWithOptions([]byte(formatSpec), openapi.LoadOptions{Formats: formats})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("value", func(t *testing.T) {
+		schema := doc.Components.Schemas["Item"]
+		valid := map[string]interface{}{"id": "01ARZ3NDEKTSV4RRFFQ69G5FAV", "createdAt": "2017-07-21T17:32:28Z", "size": 1}
+		if err := schema.ValidateValue(doc, valid); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		invalid := map[string]interface{}{"id": "01arz3", "createdAt": "yesterday", "size": 1 << 40}
+		err := schema.ValidateValue(doc, invalid)
+		if got := validationPointers(t, err); !reflect.DeepEqual(got, []string{"/createdAt", "/id", "/size"}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("parameter", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/items/01ARZ3NDEKTSV4RRFFQ69G5FAV?limit=10", nil)
+		if err := doc.ValidateRequest(r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		r = httptest.NewRequest(http.MethodGet, "/items/abc?limit=4294967296", nil)
+		err := doc.ValidateRequest(r)
+		if got := errorPointers(t, err); !reflect.DeepEqual(got, []string{"/path/id", "/query/limit"}) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("example", func(t *testing.T) {
+		if example, ok := formats.Example("x-ulid"); !ok || example != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+			t.Errorf("unexpected example: %v, %v", example, ok)
+		}
+		if _, ok := formats.Example("x-unknown"); ok {
+			t.Error("unknown format should have no example")
+		}
+	})
+}
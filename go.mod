@@ -2,4 +2,7 @@ module github.com/nasa9084/go-openapi
 
 go 1.16
 
-require gopkg.in/yaml.v2 v2.4.0
+require (
+	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -10,7 +10,7 @@ type Header struct {
 	AllowEmptyValue bool `yaml:"allowEmptyValue"`
 
 	Style         string
-	Explode       bool
+	Explode       *bool
 	AllowReserved bool `yaml:"allowReserved"`
 	Schema        *Schema
 	Example       interface{}
@@ -19,25 +19,49 @@ type Header struct {
 	Content map[string]*MediaType
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Header object.
+// The header with $ref is not validated, like Response, as the
+// referenced one is validated in the components of the Document.
 func (header Header) Validate() error {
-	validaters := []validater{}
+	return validateFirst(header)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (header Header) GetExtension(name string, v interface{}) error {
+	return getExtension(header.Extension, name, v)
+}
+
+func (header Header) validate(v *validator, ptr string) {
+	if header.Ref != "" {
+		return // validated in doc.Components
+	}
+	validateExtension(v, ptr, header.Extension)
+	if len(header.Content) > 1 {
+		v.report(joinPointer(ptr, "content"), ErrTooManyHeaderContent)
+	}
 	if header.Schema != nil {
-		validaters = append(validaters, header.Schema)
+		header.Schema.validate(v, joinPointer(ptr, "schema"))
 	}
-	if v, ok := header.Example.(validater); ok {
-		validaters = append(validaters, v)
+	if e, ok := header.Example.(pointerValidater); ok {
+		e.validate(v, joinPointer(ptr, "example"))
 	}
+	v.validateExample(header.Schema, header.Example, joinPointer(ptr, "example"))
+	v.validateExamples(header.Schema, header.Examples, joinPointer(ptr, "examples"))
 
-	// example has no validation
-
-	if len(header.Content) > 1 {
-		return ErrTooManyHeaderContent
+	for name, example := range header.Examples {
+		if example != nil {
+			example.validate(v, joinPointer(ptr, "examples", name))
+		}
 	}
-	for _, mediaType := range header.Content {
-		validaters = append(validaters, mediaType)
+
+	for mime, mediaType := range header.Content {
+		if mediaType != nil {
+			mediaType.validate(v, joinPointer(ptr, "content", mime))
+		}
 	}
-	return validateAll(validaters)
 }
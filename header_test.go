@@ -15,6 +15,13 @@ func TestHeader_Validate(t *testing.T) {
 				"image/png":        &openapi.MediaType{},
 			},
 		}, openapi.ErrTooManyHeaderContent},
+		{"reference", openapi.Header{
+			Ref: "#/components/headers/foo",
+			Content: map[string]*openapi.MediaType{
+				"application/json": &openapi.MediaType{},
+				"image/png":        &openapi.MediaType{},
+			},
+		}, nil},
 	}
 	testValidater(t, candidates)
 }
@@ -9,19 +9,33 @@ import (
 // Info Object
 type Info struct {
 	Title          string
+	Summary        string
 	Description    string
 	TermsOfService string `yaml:"termsOfService"`
 	Contact        *Contact
 	License        *License
 	Version        string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Info object.
 func (info Info) Validate() error {
+	return validateFirst(info)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (info Info) GetExtension(name string, v interface{}) error {
+	return getExtension(info.Extension, name, v)
+}
+
+func (info Info) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, info.Extension)
 	if err := info.validateRequiredFields(); err != nil {
-		return err
+		v.report(ptr, err)
 	}
-	return info.validateFields()
+	info.validateFields(v, ptr)
 }
 
 func (info Info) validateRequiredFields() error {
@@ -34,19 +48,19 @@ func (info Info) validateRequiredFields() error {
 	return nil
 }
 
-func (info Info) validateFields() error {
+func (info Info) validateFields(v *validator, ptr string) {
 	if info.TermsOfService != "" {
 		if _, err := url.ParseRequestURI(info.TermsOfService); err != nil {
-			return ErrFormatInvalid{Target: "info.termsOfService", Format: "URL"}
+			v.report(joinPointer(ptr, "termsOfService"), ErrFormatInvalid{Target: "info.termsOfService", Format: "URL"})
 		}
 	}
-
-	var validaters []validater
 	if info.Contact != nil {
-		validaters = append(validaters, info.Contact)
+		info.Contact.validate(v, joinPointer(ptr, "contact"))
+	}
+	if info.Summary != "" && v.is30() {
+		v.reportUnsupported(ptr, "summary")
 	}
 	if info.License != nil {
-		validaters = append(validaters, info.License)
+		info.License.validate(v, joinPointer(ptr, "license"))
 	}
-	return validateAll(validaters)
 }
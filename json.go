@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// parse the source of a document into a YAML node tree. The source is
+// parsed with encoding/json if it looks like JSON, otherwise as YAML.
+// As the flow style YAML also looks like JSON, the source which fails
+// to be parsed as JSON is parsed as YAML, and the error of JSON is
+// returned if it fails again.
+func parse(b []byte) (*yaml.Node, error) {
+	if isJSON(b) {
+		node, err := parseJSON(b)
+		if err == nil {
+			return node, nil
+		}
+		var yamlNode yaml.Node
+		if yaml.Unmarshal(b, &yamlNode) != nil {
+			return nil, err
+		}
+		return &yamlNode, nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// isJSON reports whether the source looks like a JSON object or array.
+func isJSON(b []byte) bool {
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf")) // BOM
+	b = bytes.TrimLeft(b, " \t\r\n")
+	return len(b) > 0 && (b[0] == '{' || b[0] == '[')
+}
+
+// jsonParser builds a YAML node tree from the tokens of JSON, so the
+// JSON source is decoded through the same path as YAML, keeping the
+// source positions and the precision of the numbers.
+type jsonParser struct {
+	src      []byte
+	dec      *json.Decoder
+	newlines []int
+}
+
+func parseJSON(b []byte) (*yaml.Node, error) {
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+	p := &jsonParser{
+		src: b,
+		dec: json.NewDecoder(bytes.NewReader(b)),
+	}
+	p.dec.UseNumber()
+	for i, c := range b {
+		if c == '\n' {
+			p.newlines = append(p.newlines, i)
+		}
+	}
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.dec.Token(); err != io.EOF {
+		return nil, p.errorf("invalid character after top-level value")
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Line: node.Line, Column: node.Column, Content: []*yaml.Node{node}}, nil
+}
+
+// offset returns the offset of the next token.
+func (p *jsonParser) offset() int {
+	off := int(p.dec.InputOffset())
+	for off < len(p.src) && strings.IndexByte(" \t\r\n:,", p.src[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// lineColumn returns 1-based line and column of the offset.
+func (p *jsonParser) lineColumn(off int) (line, column int) {
+	i := sort.SearchInts(p.newlines, off)
+	if i == 0 {
+		return 1, off + 1
+	}
+	return i + 1, off - p.newlines[i-1]
+}
+
+func (p *jsonParser) errorf(format string, args ...interface{}) error {
+	line, column := p.lineColumn(p.offset())
+	return LocatedError{
+		Position: Position{Line: line, Column: column},
+		Err:      fmt.Errorf(format, args...),
+	}
+}
+
+func (p *jsonParser) value() (*yaml.Node, error) {
+	line, column := p.lineColumn(p.offset())
+	token, err := p.dec.Token()
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// the offset is after the invalid character
+			line, column := p.lineColumn(int(syntaxErr.Offset) - 1)
+			return nil, LocatedError{Position: Position{Line: line, Column: column}, Err: err}
+		}
+		if err == io.EOF {
+			return nil, p.errorf("unexpected end of JSON input")
+		}
+		return nil, err
+	}
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: line, Column: column}
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+			for p.dec.More() {
+				key, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				if key.Tag != "!!str" {
+					return nil, p.errorf("object key must be a string")
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, key, value)
+			}
+		case '[':
+			node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+			for p.dec.More() {
+				item, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, item)
+			}
+		default:
+			return nil, p.errorf("unexpected %s", t)
+		}
+		if _, err := p.dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+	case string:
+		node.Tag, node.Value, node.Style = "!!str", t, yaml.DoubleQuotedStyle
+	case json.Number:
+		// the integers which do not fit in int64 are decoded as float
+		node.Tag, node.Value = "!!float", t.String()
+		if _, err := strconv.ParseInt(node.Value, 10, 64); err == nil {
+			node.Tag = "!!int"
+		}
+	case bool:
+		node.Tag, node.Value = "!!bool", fmt.Sprint(t)
+	case nil:
+		node.Tag, node.Value = "!!null", "null"
+	}
+	return node, nil
+}
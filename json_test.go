@@ -0,0 +1,118 @@
+package openapi_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const jsonSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "json", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {
+              "application/json": {
+                "example": {"id": 12345678901234567, "price": 1.5, "tags": ["a", "b"], "note": null, "big": 123456789012345678901234567890}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestLoadReader_JSON(t *testing.T) {
+	doc, err := openapi.LoadReader(strings.NewReader(jsonSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	example := doc.Paths["/pets"].Get.Responses["200"].Content["application/json"].Example
+	want := map[string]interface{}{
+		"id":    12345678901234567,
+		"big":   1.2345678901234568e+29,
+		"price": 1.5,
+		"tags":  []interface{}{"a", "b"},
+		"note":  nil,
+	}
+	if !reflect.DeepEqual(example, want) {
+		t.Errorf("%#v != %#v", example, want)
+	}
+	pos, ok := doc.Position("/paths/~1pets/get/responses/200/content/application~1json/example/price")
+	if !ok || pos.Line != 12 || pos.Column != 54 {
+		t.Errorf("unexpected position: %s", pos)
+	}
+}
+
+func TestLoadReader_YAML(t *testing.T) {
+	doc, err := openapi.LoadReader(strings.NewReader(`openapi: 3.0.0
+info:
+  title: yaml
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      default:
+        name: foo
+        1: one
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"name": "foo", "1": "one"}
+	if got := doc.Components.Schemas["Pet"].Default; !reflect.DeepEqual(got, want) {
+		t.Errorf("%#v != %#v", got, want)
+	}
+}
+
+func TestLoadReader_FlowStyleYAML(t *testing.T) {
+	doc, err := openapi.LoadReader(strings.NewReader(`{openapi: 3.0.0, info: {title: flow, version: 1.0.0}, paths: {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Version != "3.0.0" || doc.Info.Title != "flow" {
+		t.Errorf("unexpected document: %#v", doc)
+	}
+	pos, ok := doc.Position("/info/title")
+	if !ok || pos.Line != 1 || pos.Column != 25 {
+		t.Errorf("unexpected position: %s", pos)
+	}
+}
+
+func TestLoadReader_Error(t *testing.T) {
+	readErr := errors.New("read error")
+	if _, err := openapi.LoadReader(errReader{readErr}); err != readErr {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err := openapi.LoadReader(strings.NewReader("{\n  \"openapi\": \"3.0.0\",\n  \"info\": ]"))
+	var located openapi.LocatedError
+	if !errors.As(err, &located) {
+		t.Fatalf("error should be LocatedError: %v", err)
+	}
+	if located.Position.Line != 3 || located.Position.Column != 11 {
+		t.Errorf("unexpected position: %s", located.Position)
+	}
+
+	if _, err := openapi.LoadReader(strings.NewReader(`{"openapi": "3.0.0"`)); err == nil {
+		t.Error("error should be returned for truncated JSON")
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
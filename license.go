@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"errors"
 	"net/url"
 )
 
@@ -8,21 +9,40 @@ import (
 
 // License Object
 type License struct {
-	Name string
-	URL  string
+	Name       string
+	Identifier string
+	URL        string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of License object.
 func (license License) Validate() error {
+	return validateFirst(license)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (license License) GetExtension(name string, v interface{}) error {
+	return getExtension(license.Extension, name, v)
+}
+
+func (license License) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, license.Extension)
 	if license.Name == "" {
-		return ErrRequired{Target: "license.name"}
+		v.report(ptr, ErrRequired{Target: "license.name"})
+	}
+	if license.Identifier != "" {
+		switch {
+		case v.is30():
+			v.reportUnsupported(ptr, "identifier")
+		case license.URL != "":
+			v.report(ptr, errors.New("identifier and url are mutually exclusive"))
+		}
 	}
 	if license.URL != "" {
-		_, err := url.ParseRequestURI(license.URL)
-		if err != nil {
-			return ErrFormatInvalid{Target: "license.url", Format: "URL"}
+		if _, err := url.ParseRequestURI(license.URL); err != nil {
+			v.report(joinPointer(ptr, "url"), ErrFormatInvalid{Target: "license.url", Format: "URL"})
 		}
-		return nil
 	}
-	return nil
 }
@@ -14,24 +14,35 @@ type Link struct {
 	Server       *Server
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Link object.
 func (link Link) Validate() error {
+	return validateFirst(link)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (link Link) GetExtension(name string, v interface{}) error {
+	return getExtension(link.Extension, name, v)
+}
+
+func (link Link) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, link.Extension)
 	if link.OperationRef != "" && link.OperationID != "" {
-		return errors.New("operationRef and operationId are mutually exclusive")
+		v.report(ptr, errors.New("operationRef and operationId are mutually exclusive"))
 	}
-	validaters := []validater{}
-	for _, i := range link.Parameters {
-		if v, ok := i.(validater); ok {
-			validaters = append(validaters, v)
+	for name, i := range link.Parameters {
+		if pv, ok := i.(pointerValidater); ok {
+			pv.validate(v, joinPointer(ptr, "parameters", name))
 		}
 	}
-	if v, ok := link.RequestBody.(validater); ok {
-		validaters = append(validaters, v)
+	if pv, ok := link.RequestBody.(pointerValidater); ok {
+		pv.validate(v, joinPointer(ptr, "requestBody"))
 	}
 	if link.Server != nil {
-		validaters = append(validaters, link.Server)
+		link.Server.validate(v, joinPointer(ptr, "server"))
 	}
-	return validateAll(validaters)
 }
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Loader loads an OpenAPI Specification document and the documents
+// referenced from it, from a file system or over HTTP. The loaded
+// documents are cached in the loader, so one loader should be used
+// for a set of documents.
+type Loader struct {
+	// FS is the file system which the documents are read from.
+	// Relative references are resolved as a path in FS.
+	FS fs.FS
+	// Fetcher fetches the documents referenced by http(s) URLs.
+	// If Fetcher is nil, HTTPFetcher with http.DefaultClient is used.
+	Fetcher Fetcher
+	// Options is used for loading all the documents.
+	Options LoadOptions
+
+	// dir is prepended to the file names in source positions.
+	dir string
+	// version is the minor version of the root document, which the
+	// referenced documents are decoded as.
+	version string
+
+	mu      sync.Mutex
+	nodes   map[string]*yaml.Node
+	objects map[string]interface{}
+	maps    mapInfos
+}
+
+// LoadFS loads OpenAPI Specification v3.0 spec file from fsys.
+// The references to other files are resolved in fsys.
+func LoadFS(fsys fs.FS, name string) (*Document, error) {
+	loader := &Loader{FS: fsys}
+	return loader.Load(name)
+}
+
+// LoadURL loads OpenAPI Specification v3.0 spec from the http(s) URL.
+// The relative references are resolved based on the URL.
+func LoadURL(rawurl string) (*Document, error) {
+	loader := &Loader{}
+	return loader.Load(rawurl)
+}
+
+// Load the spec. name is a http(s) URL or a file name in loader.FS.
+func (loader *Loader) Load(name string) (*Document, error) {
+	location, err := url.Parse(name)
+	if err != nil || !isRemote(location) {
+		location = &url.URL{Path: "/" + path.Clean(name)}
+	}
+	b, err := loader.read(location)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := load(b, loader.displayName(location), loader.Options)
+	if err != nil {
+		return nil, err
+	}
+	loader.mu.Lock()
+	loader.version = minorVersion(doc.Version)
+	loader.mu.Unlock()
+	doc.loader = loader
+	doc.location = location
+	return doc, nil
+}
+
+func isRemote(location *url.URL) bool {
+	return location.Scheme == "http" || location.Scheme == "https"
+}
+
+func (loader *Loader) read(location *url.URL) ([]byte, error) {
+	switch {
+	case isRemote(location):
+		fetcher := loader.Fetcher
+		if fetcher == nil {
+			fetcher = HTTPFetcher{}
+		}
+		return fetcher.Fetch(location)
+	case location.Scheme != "":
+		return nil, errors.New("unsupported scheme: " + location.Scheme)
+	case loader.FS == nil:
+		return nil, errors.New("no file system to load " + location.String())
+	}
+	return fs.ReadFile(loader.FS, strings.TrimPrefix(location.Path, "/"))
+}
+
+// displayName returns the name of the document at the location,
+// which is used in source positions.
+func (loader *Loader) displayName(location *url.URL) string {
+	if isRemote(location) {
+		return location.String()
+	}
+	return path.Join(loader.dir, strings.TrimPrefix(location.Path, "/"))
+}
+
+// node returns the parsed document at the location. The references
+// in the document are rewritten to be absolute, because they are
+// resolved from the root document.
+func (loader *Loader) node(location *url.URL) (*yaml.Node, error) {
+	key := location.String()
+	if node, ok := loader.nodes[key]; ok {
+		return node, nil
+	}
+	b, err := loader.read(location)
+	if err != nil {
+		return nil, err
+	}
+	node, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := absolutizeRefs(node, location); err != nil {
+		return nil, err
+	}
+	if loader.nodes == nil {
+		loader.nodes = map[string]*yaml.Node{}
+	}
+	loader.nodes[key] = node
+	return node, nil
+}
+
+// resolve the object which is referenced by the absolute reference
+// ref. typ is the type of the object.
+func (loader *Loader) resolve(ref *url.URL, typ reflect.Type) (interface{}, error) {
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+
+	key := ref.String()
+	if obj, ok := loader.objects[key]; ok {
+		return obj, nil
+	}
+	location := *ref
+	location.Fragment = ""
+	node, err := loader.node(&location)
+	if err != nil {
+		return nil, err
+	}
+	target, err := lookupNode(node, ref.Fragment)
+	if err != nil {
+		return nil, err
+	}
+	obj := reflect.New(typ)
+	d := newDecoder(loader.displayName(&location))
+	d.strict = loader.Options.Strict
+	d.version = loader.version
+	if err := d.decode(target, obj.Elem(), ref.Fragment); err != nil {
+		return nil, err
+	}
+	if err := d.unknownFields(); err != nil {
+		return nil, err
+	}
+	if loader.maps == nil {
+		loader.maps = mapInfos{}
+	}
+	loader.maps.merge(d.maps)
+	if loader.objects == nil {
+		loader.objects = map[string]interface{}{}
+	}
+	loader.objects[key] = obj.Interface()
+	return obj.Interface(), nil
+}
+
+// absolutizeRefs rewrites the all $ref in the node to absolute
+// references, based on the location of the document.
+func absolutizeRefs(node *yaml.Node, location *url.URL) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			if err := absolutizeRefs(n, location); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				ref, err := url.Parse(value.Value)
+				if err != nil {
+					return err
+				}
+				abs, err := resolveLocation(location, ref)
+				if err != nil {
+					return err
+				}
+				value.Value = abs.String()
+				continue
+			}
+			if err := absolutizeRefs(value, location); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveLocation resolves the reference ref based on the location of
+// the document. As ResolveReference drops the ".." segments going up
+// from the root, the references out of the root of the file system are
+// reported instead of being resolved to a wrong file.
+func resolveLocation(location, ref *url.URL) (*url.URL, error) {
+	if !isRemote(location) && ref.Scheme == "" && ref.Host == "" && ref.Path != "" && !strings.HasPrefix(ref.Path, "/") {
+		p := path.Join(path.Dir(strings.TrimPrefix(location.Path, "/")), ref.Path)
+		if p == ".." || strings.HasPrefix(p, "../") {
+			return nil, ErrReferenceOutOfRoot{Ref: ref.String()}
+		}
+	}
+	return location.ResolveReference(ref), nil
+}
+
+// lookupNode returns the node which is pointed by the JSON pointer ptr.
+func lookupNode(node *yaml.Node, ptr string) (*yaml.Node, error) {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, errors.New("empty document")
+		}
+		node = node.Content[0]
+	}
+	if ptr == "" {
+		return node, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, ErrFormatInvalid{Target: "reference", Format: "JSON pointer"}
+	}
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = unescapePointer(token)
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && 0 <= i && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			return nil, errors.New("not found: " + ptr)
+		}
+		node = next
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node, nil
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func unescapePointer(token string) string {
+	return pointerUnescaper.Replace(token)
+}
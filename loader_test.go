@@ -0,0 +1,187 @@
+package openapi_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"api/openapi.yaml": &fstest.MapFile{Data: []byte(`openapi: 3.0.0
+info:
+  title: foo
+  version: 1.0.0
+paths: {}
+`)},
+		"api/schemas/pet.yaml": &fstest.MapFile{Data: []byte(`Pet:
+  type: object
+  properties:
+    owner:
+      $ref: 'user.yaml'
+`)},
+		"api/schemas/user.yaml": &fstest.MapFile{Data: []byte(`type: string`)},
+	}
+	doc, err := openapi.LoadFS(fsys, "api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet, err := openapi.ResolveSchema(doc, "./schemas/pet.yaml#/Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pet.Type != "object" {
+		t.Errorf("%s != object", pet.Type)
+	}
+	owner := pet.Properties["owner"]
+	if owner.Ref != "/api/schemas/user.yaml" {
+		t.Errorf("the reference should be rewritten to absolute: %s", owner.Ref)
+	}
+	user, err := openapi.ResolveSchema(doc, owner.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Type != "string" {
+		t.Errorf("%s != string", user.Type)
+	}
+
+	cached, err := openapi.ResolveSchema(doc, "schemas/pet.yaml#/Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != pet {
+		t.Error("the resolved object should be cached")
+	}
+
+	if _, err := openapi.ResolveSchema(doc, "schemas/pet.yaml#/Unknown"); err == nil {
+		t.Error("error should be occurred for unknown pointer")
+	}
+	if _, err := openapi.ResolveSchema(doc, "schemas/unknown.yaml"); err == nil {
+		t.Error("error should be occurred for unknown file")
+	}
+	if _, err := openapi.ResolveResponse(doc, "schemas/pet.yaml#/Pet"); err != openapi.ErrTypeAssertion {
+		t.Errorf("error should be ErrTypeAssertion, but %v", err)
+	}
+}
+
+func TestLoadFile_ExternalReference(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Validate(); err != nil {
+		t.Error(err)
+	}
+	op := doc.Paths["/pets"].Get
+	item := op.Responses["200"].Content["application/json"].Schema.Items
+	pet, err := openapi.ResolveSchema(doc, item.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := pet.Properties["id"]; !ok {
+		t.Error("pet.properties.id not found")
+	}
+	category, err := openapi.ResolveSchema(doc, pet.Properties["category"].Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.Properties["name"].Type != "string" {
+		t.Errorf("%s != string", category.Properties["name"].Type)
+	}
+	tag, err := openapi.ResolveSchema(doc, pet.Properties["tag"].Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Type != "string" {
+		t.Errorf("%s != string", tag.Type)
+	}
+
+	resp, err := openapi.ResolveResponse(doc, op.Responses["default"].Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Description != "unexpected error" {
+		t.Errorf("%s != unexpected error", resp.Description)
+	}
+	errSchema, err := openapi.ResolveSchema(doc, resp.Content["application/json"].Schema.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errSchema != category {
+		t.Error("the same reference should be resolved to the same object")
+	}
+}
+
+func TestResolve_WithoutLoader(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.0
+info:
+  title: foo
+  version: 1.0.0
+paths: {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openapi.ResolveSchema(doc, "schemas/pet.yaml#/Pet"); err == nil {
+		t.Error("error should be occurred")
+	}
+}
+
+func TestLoadFile_ReferenceOutOfRoot(t *testing.T) {
+	// specs/common/pet.yaml should not be loaded instead
+	doc, err := openapi.LoadFile("testdata/sibling/specs/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outOfRoot openapi.ErrReferenceOutOfRoot
+	if _, err := openapi.ResolveSchema(doc, "../common/pet.yaml#/Pet"); !errors.As(err, &outOfRoot) {
+		t.Errorf("error should be ErrReferenceOutOfRoot, but %v", err)
+	}
+
+	doc, err = openapi.LoadFS(os.DirFS("testdata/sibling"), "specs/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet, err := openapi.ResolveSchema(doc, "../common/pet.yaml#/Pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pet.Type != "object" {
+		t.Errorf("%s != object", pet.Type)
+	}
+}
+
+func TestResolve_SameDocument(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		label string
+		ref   string
+	}{
+		{"bare fragment", "#"},
+		{"current file", "openapi.yaml"},
+	}
+	for _, c := range candidates {
+		if _, err := openapi.ResolveSchema(doc, c.ref); err != openapi.ErrTypeAssertion {
+			t.Errorf("%s: the document root should be resolved: %v", c.label, err)
+		}
+	}
+	want := openapi.ErrFormatInvalid{Target: "reference", Format: "JSON pointer"}
+	for _, ref := range []string{"#components/schemas/Pets", "openapi.yaml#components/schemas/Pets"} {
+		if _, err := openapi.ResolveSchema(doc, ref); err != want {
+			t.Errorf("%s: %v != %v", ref, err, want)
+		}
+	}
+	pets, err := openapi.ResolveSchema(doc, "openapi.yaml#/components/schemas/Pets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pets.Type != "array" {
+		t.Errorf("%s != array", pets.Type)
+	}
+}
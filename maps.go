@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// mapInfos holds the information about the maps decoded from the
+// source, which cannot be held by the maps themselves. The maps are
+// identified by their pointer.
+type mapInfos map[uintptr]*mapInfo
+
+type mapInfo struct {
+	// m holds the map not to be garbage collected, so that the pointer
+	// is not reused for another map.
+	m interface{}
+	// keys holds the keys in the order of the source.
+	keys []interface{}
+	// extension holds the specification extensions of the map object,
+	// like Paths, which cannot be held as an entry of the map.
+	extension map[string]interface{}
+}
+
+// info returns the information of the map m, creating it if needed.
+func (infos mapInfos) info(m reflect.Value) *mapInfo {
+	info, ok := infos[m.Pointer()]
+	if !ok {
+		info = &mapInfo{m: m.Interface()}
+		infos[m.Pointer()] = info
+	}
+	return info
+}
+
+// recordKeys records the order of the keys of the map m.
+func (infos mapInfos) recordKeys(m reflect.Value, keys []interface{}) {
+	infos.info(m).keys = keys
+}
+
+// recordExtension records the specification extension of the map m.
+func (infos mapInfos) recordExtension(m reflect.Value, name string, value interface{}) {
+	infos.info(m).setExtension(name, value)
+}
+
+func (info *mapInfo) setExtension(name string, value interface{}) {
+	if info.extension == nil {
+		info.extension = map[string]interface{}{}
+	}
+	info.extension[name] = value
+}
+
+func (infos mapInfos) merge(other mapInfos) {
+	for p, info := range other {
+		infos[p] = info
+	}
+}
+
+// mapKeys returns the keys of the map m. The keys recorded in the
+// order come first in the recorded order, followed by the others
+// sorted.
+func (infos mapInfos) mapKeys(m reflect.Value) []reflect.Value {
+	keys := make([]reflect.Value, 0, m.Len())
+	seen := map[interface{}]struct{}{}
+	if recorded, ok := infos[m.Pointer()]; ok {
+		for _, k := range recorded.keys {
+			key := reflect.ValueOf(k)
+			if !key.Type().AssignableTo(m.Type().Key()) {
+				continue
+			}
+			if _, ok := seen[k]; ok || !m.MapIndex(key).IsValid() {
+				continue
+			}
+			seen[k] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	var rest []reflect.Value
+	for _, key := range m.MapKeys() {
+		if _, ok := seen[key.Interface()]; !ok {
+			rest = append(rest, key)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		return fmt.Sprint(rest[i].Interface()) < fmt.Sprint(rest[j].Interface())
+	})
+	return append(keys, rest...)
+}
+
+// Keys returns the keys of the map in the document, in the order they
+// appear in the source. The keys which do not appear in the source,
+// like the ones added after loading, follow them in sorted order.
+// m must be a map in the document, like Paths, Responses or
+// Schema.Properties. If m is not a map, Keys returns nil.
+func (doc *Document) Keys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+	keys := doc.mapKeys(v)
+	ret := make([]string, len(keys))
+	for i, key := range keys {
+		ret[i] = fmt.Sprint(key.Interface())
+	}
+	return ret
+}
+
+// PathKeys returns the paths of the document in the source order.
+func (doc *Document) PathKeys() []string {
+	return doc.Keys(doc.Paths)
+}
+
+// SchemaKeys returns the names of the schemas in the components of
+// the document in the source order.
+func (doc *Document) SchemaKeys() []string {
+	if doc.Components == nil {
+		return nil
+	}
+	return doc.Keys(doc.Components.Schemas)
+}
+
+// ResponseKeys returns the status codes of the responses in the
+// source order.
+func (doc *Document) ResponseKeys(responses Responses) []string {
+	return doc.Keys(responses)
+}
+
+// PropertyKeys returns the property names of the schema in the source
+// order.
+func (doc *Document) PropertyKeys(schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+	return doc.Keys(schema.Properties)
+}
+
+// CallbackKeys returns the expressions of the callback in the source
+// order.
+func (doc *Document) CallbackKeys(callback Callback) []string {
+	return doc.Keys(callback)
+}
+
+// mapKeys returns the keys of the map in the document in the source
+// order.
+func (doc *Document) mapKeys(m reflect.Value) []reflect.Value {
+	if m.IsNil() {
+		return nil
+	}
+	return mapInfos{m.Pointer(): doc.mapInfo(m)}.mapKeys(m)
+}
+
+// mapInfo returns the information of the map in the document. The maps
+// loaded from other documents are looked up in the loader. If the map
+// is not decoded from the source, mapInfo returns an empty one.
+func (doc *Document) mapInfo(m reflect.Value) *mapInfo {
+	if info, ok := doc.maps[m.Pointer()]; ok {
+		return info
+	}
+	if doc.loader != nil {
+		doc.loader.mu.Lock()
+		defer doc.loader.mu.Unlock()
+		if info, ok := doc.loader.maps[m.Pointer()]; ok {
+			return info
+		}
+	}
+	return &mapInfo{}
+}
+
+// updateMapInfo calls f with the information of the map in the
+// document. If the map is not decoded from the source, the information
+// is created in the document.
+func (doc *Document) updateMapInfo(m reflect.Value, f func(info *mapInfo)) {
+	if info, ok := doc.maps[m.Pointer()]; ok {
+		f(info)
+		return
+	}
+	if doc.loader != nil {
+		doc.loader.mu.Lock()
+		info, ok := doc.loader.maps[m.Pointer()]
+		if ok {
+			f(info)
+		}
+		doc.loader.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	if doc.maps == nil {
+		doc.maps = mapInfos{}
+	}
+	f(doc.maps.info(m))
+}
@@ -8,23 +8,42 @@ type MediaType struct {
 	Example  interface{}
 	Examples map[string]*Example
 	Encoding map[string]*Encoding
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of MediaType object.
 // This function DOES NOT check whether the encoding object is in schema or not.
 func (mediaType MediaType) Validate() error {
-	validaters := []validater{}
+	return validateFirst(mediaType)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (mediaType MediaType) GetExtension(name string, v interface{}) error {
+	return getExtension(mediaType.Extension, name, v)
+}
+
+func (mediaType MediaType) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, mediaType.Extension)
 	if mediaType.Schema != nil {
-		validaters = append(validaters, mediaType.Schema)
+		mediaType.Schema.validate(v, joinPointer(ptr, "schema"))
 	}
-	if v, ok := mediaType.Example.(validater); ok {
-		validaters = append(validaters, v)
+	if e, ok := mediaType.Example.(pointerValidater); ok {
+		e.validate(v, joinPointer(ptr, "example"))
 	}
+	v.validateExample(mediaType.Schema, mediaType.Example, joinPointer(ptr, "example"))
+	v.validateExamples(mediaType.Schema, mediaType.Examples, joinPointer(ptr, "examples"))
 
-	// example has no validation
+	for name, example := range mediaType.Examples {
+		if example != nil {
+			example.validate(v, joinPointer(ptr, "examples", name))
+		}
+	}
 
-	for _, e := range mediaType.Encoding {
-		validaters = append(validaters, e)
+	for name, e := range mediaType.Encoding {
+		if e != nil {
+			e.validate(v, joinPointer(ptr, "encoding", name))
+		}
 	}
-	return validateAll(validaters)
 }
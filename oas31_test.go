@@ -0,0 +1,420 @@
+package openapi_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const oas31Spec = `openapi: 3.1.0
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+info:
+  title: webhooks
+  summary: webhook only API
+  version: 1.0.0
+  license:
+    name: Apache 2.0
+    identifier: Apache-2.0
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: [object, 'null']
+      properties:
+        kind:
+          const: pet
+        age:
+          type: integer
+          exclusiveMinimum: 0
+        point:
+          type: array
+          prefixItems:
+            - $ref: '#/components/schemas/Pet/$defs/coordinate'
+            - $ref: '#/components/schemas/Pet/$defs/coordinate'
+      $defs:
+        coordinate:
+          type: number
+`
+
+func TestLoad_OAS31(t *testing.T) {
+	doc, err := openapi.Load([]byte(oas31Spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !doc.IsOAS31() {
+		t.Error("IsOAS31() should be true")
+	}
+	if doc.JSONSchemaDialect != "https://spec.openapis.org/oas/3.1/dialect/base" {
+		t.Errorf("unexpected jsonSchemaDialect: %s", doc.JSONSchemaDialect)
+	}
+	if doc.Info.Summary != "webhook only API" {
+		t.Errorf("unexpected info.summary: %s", doc.Info.Summary)
+	}
+	if doc.Info.License.Identifier != "Apache-2.0" {
+		t.Errorf("unexpected license.identifier: %s", doc.Info.License.Identifier)
+	}
+	if _, ok := doc.Webhooks["newPet"]; !ok {
+		t.Error("webhook newPet is not loaded")
+	}
+	pet := doc.Components.Schemas["Pet"]
+	if got, want := pet.TypeNames(), []string{"object", "null"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+	if pet.Properties["kind"].Const != "pet" {
+		t.Errorf("unexpected const: %v", pet.Properties["kind"].Const)
+	}
+	age := pet.Properties["age"]
+	if age.ExclusiveMinimumValue == nil || *age.ExclusiveMinimumValue != 0 {
+		t.Errorf("unexpected exclusiveMinimum: %v", age.ExclusiveMinimumValue)
+	}
+	if len(pet.Properties["point"].PrefixItems) != 2 {
+		t.Errorf("unexpected prefixItems: %v", pet.Properties["point"].PrefixItems)
+	}
+	if pet.Defs["coordinate"].Type != "number" {
+		t.Errorf("unexpected $defs: %v", pet.Defs)
+	}
+	if err := doc.ValidateAll(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_OAS31FieldsIn30(t *testing.T) {
+	doc, err := openapi.Load([]byte(strings.Replace(oas31Spec, "openapi: 3.1.0", "openapi: 3.0.3\npaths: {}", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateAll()
+	var errs openapi.ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("error should be ErrorList: %v", err)
+	}
+	want := map[string]bool{
+		"/jsonSchemaDialect":                                      true,
+		"/webhooks":                                               true,
+		"/info/summary":                                           true,
+		"/info/license/identifier":                                true,
+		"/components/schemas/Pet/type":                            true,
+		"/components/schemas/Pet/$defs":                           true,
+		"/components/schemas/Pet/properties/kind/const":           true,
+		"/components/schemas/Pet/properties/age/exclusiveMinimum": true,
+		"/components/schemas/Pet/properties/point/prefixItems":    true,
+	}
+	for _, e := range errs {
+		if !want[e.Pointer] {
+			t.Errorf("unexpected error: %v", e)
+		}
+		delete(want, e.Pointer)
+	}
+	for ptr := range want {
+		t.Errorf("%s is not reported", ptr)
+	}
+}
+
+func TestValidate_OAS31(t *testing.T) {
+	tests := []struct {
+		label string
+		spec  string
+		want  string
+	}{
+		{
+			label: "no paths, components nor webhooks",
+			spec: `openapi: 3.1.0
+info:
+  title: empty
+  version: 1.0.0
+`,
+			want: "paths, components or webhooks",
+		},
+		{
+			label: "license identifier and url",
+			spec: `openapi: 3.1.0
+info:
+  title: license
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+    url: https://opensource.org/licenses/MIT
+paths: {}
+`,
+			want: "identifier and url are mutually exclusive",
+		},
+		{
+			label: "boolean exclusiveMaximum",
+			spec: `openapi: 3.1.0
+info:
+  title: boolean exclusiveMaximum
+  version: 1.0.0
+components:
+  schemas:
+    Age:
+      type: integer
+      maximum: 10
+      exclusiveMaximum: true
+`,
+			want: "exclusiveMaximum",
+		},
+		{
+			label: "nullable",
+			spec: `openapi: 3.1.0
+info:
+  title: nullable
+  version: 1.0.0
+components:
+  schemas:
+    Name:
+      type: string
+      nullable: true
+`,
+			want: "nullable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			doc, err := openapi.Load([]byte(tt.spec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = doc.ValidateAll()
+			if err == nil {
+				t.Fatal("error should be returned")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error should contain %q: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDocument_WalkWebhooks(t *testing.T) {
+	doc, err := openapi.Load([]byte(oas31Spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err = doc.WalkWebhooks(func(doc *openapi.Document, method, path string, pathItem *openapi.PathItem, op *openapi.Operation) error {
+		got = append(got, method+" "+path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"POST newPet"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+}
+
+func TestDocument_Dereference_OAS31Siblings(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: siblings
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+    Dog:
+      $ref: '#/components/schemas/Pet'
+      description: a dog
+    Cat:
+      $ref: '#/components/schemas/Pet'
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Dereference(); err != nil {
+		t.Fatal(err)
+	}
+	pet := doc.Components.Schemas["Pet"]
+	dog := doc.Components.Schemas["Dog"]
+	if dog.Ref != "" || dog.Description != "a dog" {
+		t.Errorf("siblings of $ref should be kept: %+v", dog)
+	}
+	if len(dog.AllOf) != 1 || dog.AllOf[0] != pet {
+		t.Errorf("referenced schema should be appended to allOf: %+v", dog.AllOf)
+	}
+	if cat := doc.Components.Schemas["Cat"]; cat != pet {
+		t.Errorf("$ref without siblings should be replaced: %+v", cat)
+	}
+}
+
+func TestDocument_Marshal_OAS31(t *testing.T) {
+	doc, err := openapi.Load([]byte(oas31Spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := doc.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"type":["object","null"]`, `"exclusiveMinimum":0`, `"$defs":`, `"prefixItems":`, `"const":"pet"`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s should contain %s", b, want)
+		}
+	}
+	got, err := openapi.Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Components, doc.Components) {
+		t.Errorf("components are not round-tripped:\n%+v\n%+v", got.Components, doc.Components)
+	}
+}
+
+func TestLoadStrict_OAS31(t *testing.T) {
+	spec := `openapi: %s
+info:
+  title: strict
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      unevaluatedProperties: false
+`
+	if _, err := openapi.LoadStrict([]byte(strings.Replace(spec, "%s", "3.1.0", 1))); err != nil {
+		t.Errorf("JSON Schema keywords should be allowed in 3.1: %v", err)
+	}
+	if _, err := openapi.LoadStrict([]byte(strings.Replace(spec, "%s", "3.0.3", 1))); err == nil {
+		t.Error("unknown schema keyword should be reported in 3.0")
+	}
+}
+
+func TestLoad_OAS31BooleanSchema(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: boolean schema
+  version: 1.0.0
+components:
+  schemas:
+    Empty:
+      type: array
+      items: false
+    Anything:
+      not: true
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        x: false
+      additionalProperties: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := doc.Components.Schemas
+	if b := schemas["Empty"].Items.Bool; b == nil || *b {
+		t.Errorf("items should be false schema: %+v", schemas["Empty"].Items)
+	}
+	if b := schemas["Pet"].AdditionalPropertiesAllowed; b == nil || *b {
+		t.Errorf("additionalProperties should be kept as AdditionalPropertiesAllowed: %+v", schemas["Pet"])
+	}
+	candidates := []struct {
+		schema string
+		value  interface{}
+		valid  bool
+	}{
+		{"Empty", []interface{}{}, true},
+		{"Empty", []interface{}{1}, false},
+		{"Anything", "a", false},
+		{"Pet", map[string]interface{}{"name": "kitty"}, true},
+		{"Pet", map[string]interface{}{"name": "kitty", "x": 1}, false},
+	}
+	for _, c := range candidates {
+		if err := schemas[c.schema].ValidateValue(doc, c.value); (err == nil) != c.valid {
+			t.Errorf("%s %v: unexpected result: %v", c.schema, c.value, err)
+		}
+	}
+
+	b, err := doc.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"items":false`, `"not":true`, `"x":false`, `"additionalProperties":false`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s should contain %s", b, want)
+		}
+	}
+
+	if _, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: boolean schema
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Empty:
+      items: false
+`)); err == nil {
+		t.Error("boolean schema should not be allowed in 3.0")
+	}
+}
+
+func TestValidate_OAS31SecurityInWebhooksAndCallbacks(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: security
+  version: 1.0.0
+paths:
+  /subscribe:
+    post:
+      operationId: subscribe
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              operationId: onEvent
+              security:
+                - k: []
+              responses:
+                '200':
+                  description: ok
+      responses:
+        '201':
+          description: subscribed
+webhooks:
+  ev:
+    post:
+      operationId: ev
+      security:
+        - k: []
+      responses:
+        '200':
+          description: ok
+components:
+  pathItems:
+    Shared:
+      get:
+        operationId: shared
+        security:
+          - k: []
+        responses:
+          '200':
+            description: ok
+  securitySchemes:
+    k:
+      type: apiKey
+      name: X-API-Key
+      in: header
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ValidateAll(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
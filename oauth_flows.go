@@ -10,33 +10,37 @@ type OAuthFlows struct {
 	Password          *OAuthFlow
 	ClientCredentials *OAuthFlow `yaml:"clientCredentials"`
 	AuthorizationCode *OAuthFlow `yaml:"authorizationCode"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of OAuthFlows Object.
 func (oauthFlows OAuthFlows) Validate() error {
+	return validateFirst(oauthFlows)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (oauthFlows OAuthFlows) GetExtension(name string, v interface{}) error {
+	return getExtension(oauthFlows.Extension, name, v)
+}
+
+func (oauthFlows OAuthFlows) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, oauthFlows.Extension)
 	if oauthFlows.Implicit != nil {
 		oauthFlows.Implicit.SetFlowType(oauth.ImplicitFlow)
-		if err := oauthFlows.Implicit.Validate(); err != nil {
-			return err
-		}
+		oauthFlows.Implicit.validate(v, joinPointer(ptr, oauth.ImplicitFlow))
 	}
 	if oauthFlows.Password != nil {
 		oauthFlows.Password.SetFlowType(oauth.PasswordFlow)
-		if err := oauthFlows.Password.Validate(); err != nil {
-			return err
-		}
+		oauthFlows.Password.validate(v, joinPointer(ptr, oauth.PasswordFlow))
 	}
 	if oauthFlows.ClientCredentials != nil {
 		oauthFlows.ClientCredentials.SetFlowType(oauth.ClientCredentialsFlow)
-		if err := oauthFlows.ClientCredentials.Validate(); err != nil {
-			return err
-		}
+		oauthFlows.ClientCredentials.validate(v, joinPointer(ptr, oauth.ClientCredentialsFlow))
 	}
 	if oauthFlows.AuthorizationCode != nil {
 		oauthFlows.AuthorizationCode.SetFlowType(oauth.AuthorizationCodeFlow)
-		if err := oauthFlows.AuthorizationCode.Validate(); err != nil {
-			return err
-		}
+		oauthFlows.AuthorizationCode.validate(v, joinPointer(ptr, oauth.AuthorizationCodeFlow))
 	}
-	return nil
 }
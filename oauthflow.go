@@ -15,6 +15,8 @@ type OAuthFlow struct {
 	TokenURL         string `yaml:"tokenUrl"`
 	RefreshURL       string `yaml:"refreshUrl"`
 	Scopes           map[string]string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 var defined = struct{}{}
@@ -44,27 +46,37 @@ func (oauthFlow *OAuthFlow) SetFlowType(typ string) {
 
 // Validate the values of OAuthFlow object.
 func (oauthFlow OAuthFlow) Validate() error {
+	return validateFirst(oauthFlow)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (oauthFlow OAuthFlow) GetExtension(name string, v interface{}) error {
+	return getExtension(oauthFlow.Extension, name, v)
+}
+
+func (oauthFlow OAuthFlow) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, oauthFlow.Extension)
 	if _, ok := validFlowTypes[oauthFlow.flowType]; !ok {
-		return ErrInvalidFlowType
+		v.report(ptr, ErrInvalidFlowType)
+		return
 	}
 	if _, ok := requireAuthorizationURL[oauthFlow.flowType]; ok {
 		if err := mustURL("oauthFlow.authorizationUrl", oauthFlow.AuthorizationURL); err != nil {
-			return err
+			v.report(joinPointer(ptr, "authorizationUrl"), err)
 		}
 	}
 	if _, ok := requireTokenURL[oauthFlow.flowType]; ok {
 		if err := mustURL("oauthFlow.tokenUrl", oauthFlow.TokenURL); err != nil {
-			return err
+			v.report(joinPointer(ptr, "tokenUrl"), err)
 		}
 	}
 	if oauthFlow.RefreshURL != "" {
 		if _, err := url.ParseRequestURI(oauthFlow.RefreshURL); err != nil {
-			return ErrFormatInvalid{Target: "oauthFlow.refreshUrl", Format: "URL"}
+			v.report(joinPointer(ptr, "refreshUrl"), ErrFormatInvalid{Target: "oauthFlow.refreshUrl", Format: "URL"})
 		}
 	}
 	if oauthFlow.Scopes == nil || len(oauthFlow.Scopes) == 0 {
-		return ErrRequired{Target: "oauthFlow.scopes"}
+		v.report(ptr, ErrRequired{Target: "oauthFlow.scopes"})
 	}
-
-	return nil
 }
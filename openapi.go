@@ -1,52 +1,147 @@
 package openapi
 
 import (
-	"bytes"
 	"io"
 	"os"
-
-	yaml "gopkg.in/yaml.v2"
+	"path/filepath"
+	"reflect"
 )
 
 // LoadFile OpenAPI Specification v3.0 spec file.
+// The references to other files are resolved relative to the file,
+// in the directory of the file. The references going up out of the
+// directory cannot be resolved, so use LoadFS with the file system
+// which contains all the files for them.
 func LoadFile(filename string) (*Document, error) {
-	f, err := os.Open(filename)
-	if err != nil {
-		return nil, err
+	dir, name := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
 	}
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, f); err != nil {
-		return nil, err
-	}
-	b := buf.Bytes()
-
-	if err := f.Close(); err != nil {
-		panic(err)
+	loader := &Loader{
+		FS:  os.DirFS(dir),
+		dir: filepath.ToSlash(dir),
 	}
+	return loader.Load(name)
+}
 
-	return Load(b)
+// LoadOptions is the options for loading a spec.
+type LoadOptions struct {
+	// Strict makes loading fail if the spec has unknown fields, which
+	// are neither defined in the specification nor specification
+	// extensions. The returned error is ErrorList which holds all the
+	// unknown fields with their locations.
+	Strict bool
+	// Formats is the registry of the formats used to validate and
+	// decode the values against the schemas in the spec. If nil,
+	// DefaultFormats is used.
+	Formats *FormatRegistry
 }
 
 // Load OpenAPI Specification v3.0 spec.
 func Load(b []byte) (*Document, error) {
+	return LoadWithOptions(b, LoadOptions{})
+}
+
+// LoadReader loads OpenAPI Specification v3.0 spec from r.
+// The spec is parsed as JSON if it looks like JSON, otherwise as YAML.
+func LoadReader(r io.Reader) (*Document, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Load(b)
+}
+
+// LoadStrict loads OpenAPI Specification v3.0 spec in strict mode.
+// See LoadOptions.Strict.
+func LoadStrict(b []byte) (*Document, error) {
+	return LoadWithOptions(b, LoadOptions{Strict: true})
+}
+
+// LoadWithOptions loads OpenAPI Specification v3.0 spec with options.
+func LoadWithOptions(b []byte, opts LoadOptions) (*Document, error) {
+	return load(b, "", opts)
+}
+
+// load decodes the spec through a YAML node tree to keep the source
+// position of each object. filename is used only for the positions.
+func load(b []byte, filename string, opts LoadOptions) (*Document, error) {
+	node, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
 	doc := &Document{}
-	if err := yaml.Unmarshal(b, doc); err != nil {
+	d := newDecoder(filename)
+	d.strict = opts.Strict
+	d.version = documentVersion(node)
+	if err := d.decode(node, reflect.ValueOf(doc).Elem(), ""); err != nil {
+		return nil, err
+	}
+	if err := d.unknownFields(); err != nil {
 		return nil, err
 	}
+	doc.positions = d.positions
+	doc.maps = d.maps
+	doc.formats = opts.Formats
 	// If the servers property is not provided, or is an empty array, the default value would be a Server Object with a url value of /.
 	// see: https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.2.md#oasObject
 	if doc.Servers == nil || len(doc.Servers) == 0 {
 		doc.Servers = []*Server{&Server{URL: "/"}}
 	}
+	doc.setSecurityDocument()
+	return doc, nil
+}
+
+// setSecurityDocument sets the document to the security requirements
+// in it, to look up the security schemes. The operations in the paths,
+// the webhooks, the callbacks and the path items of the components are
+// walked.
+func (doc *Document) setSecurityDocument() {
 	for i := range doc.Security {
 		doc.Security[i].setDocument(doc)
 	}
+	visited := map[*PathItem]struct{}{}
 	for _, pi := range doc.Paths {
-		for _, op := range pi.Operations() {
-			for _, sr := range op.Security {
+		doc.setPathItemSecurityDocument(pi, visited)
+	}
+	for _, pi := range doc.Webhooks {
+		doc.setPathItemSecurityDocument(pi, visited)
+	}
+	if doc.Components != nil {
+		for _, pi := range doc.Components.PathItems {
+			doc.setPathItemSecurityDocument(pi, visited)
+		}
+		for _, callback := range doc.Components.Callbacks {
+			doc.setCallbackSecurityDocument(callback, visited)
+		}
+	}
+}
+
+func (doc *Document) setPathItemSecurityDocument(pi *PathItem, visited map[*PathItem]struct{}) {
+	if pi == nil {
+		return
+	}
+	if _, ok := visited[pi]; ok {
+		return
+	}
+	visited[pi] = struct{}{}
+	for _, op := range pi.Operations() {
+		for _, sr := range op.Security {
+			if sr != nil {
 				sr.setDocument(doc)
 			}
 		}
+		for _, callback := range op.Callbacks {
+			doc.setCallbackSecurityDocument(callback, visited)
+		}
+	}
+}
+
+func (doc *Document) setCallbackSecurityDocument(callback *Callback, visited map[*PathItem]struct{}) {
+	if callback == nil {
+		return
+	}
+	for _, pi := range *callback {
+		doc.setPathItemSecurityDocument(pi, visited)
 	}
-	return doc, nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"testing/fstest"
 
 	openapi "github.com/nasa9084/go-openapi"
 )
@@ -48,32 +49,26 @@ func testTestSpec(t *testing.T) {
 	}
 }
 
+// eqDocument compares the object model of the documents.
+// The source information held by a loaded document is not compared.
 func eqDocument(t *testing.T, a, b openapi.Document) {
-	if !reflect.DeepEqual(a, b) {
-		t.Errorf("document is not valid: %+v != %+v", a, b)
-		if !reflect.DeepEqual(a.Version, b.Version) {
-			t.Log("document.Version is not valid")
-		}
-		if !reflect.DeepEqual(a.Info, b.Info) {
-			t.Log("document.Info is not valid")
-		}
-		if !reflect.DeepEqual(a.Servers, b.Servers) {
-			t.Log("document.Servers is not valid")
-		}
-		if !reflect.DeepEqual(a.Paths, b.Paths) {
-			t.Log("document.Paths is not valid")
-		}
-		if !reflect.DeepEqual(a.Components, b.Components) {
-			t.Log("document.Components is not valid")
-		}
-		if !reflect.DeepEqual(a.Security, b.Security) {
-			t.Log("document.Security is not valid")
-		}
-		if !reflect.DeepEqual(a.Tags, b.Tags) {
-			t.Log("document.Tags is not valid")
-		}
-		if !reflect.DeepEqual(a.ExternalDocs, b.ExternalDocs) {
-			t.Log("document.ExternalDocs is not valid")
+	t.Helper()
+	fields := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"Version", a.Version, b.Version},
+		{"Info", a.Info, b.Info},
+		{"Servers", a.Servers, b.Servers},
+		{"Paths", a.Paths, b.Paths},
+		{"Components", a.Components, b.Components},
+		{"Security", a.Security, b.Security},
+		{"Tags", a.Tags, b.Tags},
+		{"ExternalDocs", a.ExternalDocs, b.ExternalDocs},
+	}
+	for _, f := range fields {
+		if !reflect.DeepEqual(f.a, f.b) {
+			t.Errorf("document.%s is not valid: %+v != %+v", f.name, f.a, f.b)
 		}
 	}
 }
@@ -794,7 +789,7 @@ func testLinkExample(t *testing.T) {
 						In:   "query",
 						Schema: &openapi.Schema{
 							Type: "string",
-							Enum: []string{
+							Enum: []interface{}{
 								"open",
 								"merged",
 								"declined",
@@ -1048,25 +1043,25 @@ func testAPIWithExample(t *testing.T) {
 	expect.Paths = expectPaths
 	root200Examples := map[string]*openapi.Example{
 		"foo": &openapi.Example{
-			Value: map[interface{}]interface{}{
+			Value: map[string]interface{}{
 				"versions": []interface{}{
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"status":  "CURRENT",
 						"updated": "2011-01-21T11:33:21Z",
 						"id":      "v2.0",
 						"links": []interface{}{
-							map[interface{}]interface{}{
+							map[string]interface{}{
 								"href": "http://127.0.0.1:8774/v2/",
 								"rel":  "self",
 							},
 						},
 					},
-					map[interface{}]interface{}{
+					map[string]interface{}{
 						"status":  "EXPERIMENTAL",
 						"updated": "2013-07-23T11:33:21Z",
 						"id":      "v3.0",
 						"links": []interface{}{
-							map[interface{}]interface{}{
+							map[string]interface{}{
 								"href": "http://127.0.0.1:8774/v3/",
 								"rel":  "self",
 							},
@@ -1109,37 +1104,37 @@ func testAPIWithExample(t *testing.T) {
 	}
 	v2200Examples := map[string]*openapi.Example{
 		"foo": &openapi.Example{
-			Value: map[interface{}]interface{}{
-				"version": map[interface{}]interface{}{
+			Value: map[string]interface{}{
+				"version": map[string]interface{}{
 					"status":  "CURRENT",
 					"updated": "2011-01-21T11:33:21Z",
 					"media-types": []interface{}{
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"base": "application/xml",
 							"type": "application/vnd.openstack.compute+xml;version=2",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"base": "application/json",
 							"type": "application/vnd.openstack.compute+json;version=2",
 						},
 					},
 					"id": "v2.0",
 					"links": []interface{}{
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://127.0.0.1:8774/v2/",
 							"rel":  "self",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://docs.openstack.org/api/openstack-compute/2/os-compute-devguide-2.pdf",
 							"type": "application/pdf",
 							"rel":  "describedby",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://docs.openstack.org/api/openstack-compute/2/wadl/os-compute-2.wadl",
 							"type": "application/vnd.sun.wadl+xml",
 							"rel":  "describedby",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://docs.openstack.org/api/openstack-compute/2/wadl/os-compute-2.wadl",
 							"type": "application/vnd.sun.wadl+xml",
 							"rel":  "describedby",
@@ -1151,32 +1146,32 @@ func testAPIWithExample(t *testing.T) {
 	}
 	v2203Examples := map[string]*openapi.Example{
 		"foo": &openapi.Example{
-			Value: map[interface{}]interface{}{
-				"version": map[interface{}]interface{}{
+			Value: map[string]interface{}{
+				"version": map[string]interface{}{
 					"status":  "CURRENT",
 					"updated": "2011-01-21T11:33:21Z",
 					"media-types": []interface{}{
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"base": "application/xml",
 							"type": "application/vnd.openstack.compute+xml;version=2",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"base": "application/json",
 							"type": "application/vnd.openstack.compute+json;version=2",
 						},
 					},
 					"id": "v2.0",
 					"links": []interface{}{
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://23.253.228.211:8774/v2/",
 							"rel":  "self",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://docs.openstack.org/api/openstack-compute/2/os-compute-devguide-2.pdf",
 							"type": "application/pdf",
 							"rel":  "describedby",
 						},
-						map[interface{}]interface{}{
+						map[string]interface{}{
 							"href": "http://docs.openstack.org/api/openstack-compute/2/wadl/os-compute-2.wadl",
 							"type": "application/vnd.sun.wadl+xml",
 							"rel":  "describedby",
@@ -1254,16 +1249,16 @@ func testUspto(t *testing.T) {
 								Schema: &openapi.Schema{
 									Ref: "#/components/schemas/dataSetList",
 								},
-								Example: map[interface{}]interface{}{
+								Example: map[string]interface{}{
 									"total": 2,
 									"apis": []interface{}{
-										map[interface{}]interface{}{
+										map[string]interface{}{
 											"apiKey":              "oa_citations",
 											"apiVersionNumber":    "v1",
 											"apiUrl":              "https://developer.uspto.gov/ds-api/oa_citations/v1/fields",
 											"apiDocumentationUrl": "https://developer.uspto.gov/ds-api-docs/index.html?url=https://developer.uspto.gov/ds-api/swagger/docs/oa_citations.json",
 										},
-										map[interface{}]interface{}{
+										map[string]interface{}{
 											"apiKey":              "cancer_moonshot",
 											"apiVersionNumber":    "v1",
 											"apiUrl":              "https://developer.uspto.gov/ds-api/cancer_moonshot/v1/fields",
@@ -1450,3 +1445,97 @@ func testUspto(t *testing.T) {
 	expect.Components = expectComponents
 	eqDocument(t, *doc, expect)
 }
+
+func TestLoadStrict(t *testing.T) {
+	doc, err := openapi.LoadStrict([]byte(`openapi: 3.0.0
+info:
+  title: strict
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationID: createPet
+      requestbody:
+        content:
+          application/json:
+            schema:
+              type: object
+              discriminator:
+                propertyName: kind
+                x-discriminator: ok
+      responses:
+        '201':
+          description: created
+          x-response: ok
+      responce: typo
+`))
+	if doc != nil {
+		t.Error("document should not be returned")
+	}
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList: %v", err)
+	}
+	want := []struct {
+		ptr  string
+		name string
+		line int
+	}{
+		{"/paths/~1pets/post/operationID", "operationID", 8},
+		{"/paths/~1pets/post/requestbody", "requestbody", 9},
+		{"/paths/~1pets/post/responce", "responce", 21},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for i, w := range want {
+		if errs[i].Pointer != w.ptr || errs[i].Err != (openapi.ErrUnknownField{Name: w.name}) || errs[i].Position.Line != w.line {
+			t.Errorf("unexpected error: %v", errs[i])
+		}
+	}
+}
+
+func TestLoadStrict_Testdata(t *testing.T) {
+	for _, filename := range roundTripSpecs {
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := openapi.LoadStrict(b); err != nil {
+			t.Errorf("%s: %v", filename, err)
+		}
+	}
+}
+
+func TestLoader_Strict(t *testing.T) {
+	fsys := fstest.MapFS{
+		"openapi.yaml": {Data: []byte(`openapi: 3.0.0
+info:
+  title: strict
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'
+`)},
+		"pet.yaml": {Data: []byte(`type: object
+propreties:
+  name:
+    type: string
+`)},
+	}
+	loader := &openapi.Loader{FS: fsys, Options: openapi.LoadOptions{Strict: true}}
+	doc, err := loader.Load("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = openapi.ResolveSchema(doc, "pet.yaml")
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList: %#v", err)
+	}
+	if len(errs) != 1 || errs[0].Pointer != "/propreties" || errs[0].Position.String() != "pet.yaml:2:1" {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
@@ -20,6 +20,8 @@ type Operation struct {
 	Deprecated   bool
 	Security     []*SecurityRequirement
 	Servers      []*Server
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // SuccessResponse returns a success response object.
@@ -53,29 +55,53 @@ func (operation *Operation) SuccessResponse() (*Response, int, bool) {
 }
 
 // Validate the values of Operation object.
+// The first error is returned in the order of the duplicated
+// parameters, the missing responses, and the errors of the external
+// documentation, the parameters, the request body, the responses, the
+// callbacks, the security requirements and the servers.
 func (operation Operation) Validate() error {
-	validaters := []validater{}
+	return validateFirst(operation)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (operation Operation) GetExtension(name string, v interface{}) error {
+	return getExtension(operation.Extension, name, v)
+}
+
+func (operation Operation) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, operation.Extension)
+	if hasDuplicatedParameter(operation.Parameters) {
+		v.report(joinPointer(ptr, "parameters"), ErrParameterDuplicated)
+	}
+	if operation.Responses == nil {
+		v.report(ptr, ErrRequired{Target: "operation.responses"})
+	}
 	if operation.ExternalDocs != nil {
-		validaters = append(validaters, operation.ExternalDocs)
+		operation.ExternalDocs.validate(v, joinPointer(ptr, "externalDocs"))
 	}
-	if hasDuplicatedParameter(operation.Parameters) {
-		return ErrParameterDuplicated
+	for i, parameter := range operation.Parameters {
+		if parameter != nil {
+			parameter.validate(v, joinPointer(ptr, "parameters", strconv.Itoa(i)))
+		}
 	}
 	if operation.RequestBody != nil {
-		validaters = append(validaters, operation.RequestBody)
-	}
-	if operation.Responses == nil {
-		return ErrRequired{Target: "operation.responses"}
+		operation.RequestBody.validate(v, joinPointer(ptr, "requestBody"))
 	}
-	validaters = append(validaters, operation.Responses)
-	for _, callback := range operation.Callbacks {
-		validaters = append(validaters, callback)
+	operation.Responses.validate(v, joinPointer(ptr, "responses"))
+	for name, callback := range operation.Callbacks {
+		if callback != nil {
+			callback.validate(v, joinPointer(ptr, "callbacks", name))
+		}
 	}
-	for _, security := range operation.Security {
-		validaters = append(validaters, security)
+	for i, security := range operation.Security {
+		if security != nil {
+			security.validate(v, joinPointer(ptr, "security", strconv.Itoa(i)))
+		}
 	}
-	for _, server := range operation.Servers {
-		validaters = append(validaters, server)
+	for i, server := range operation.Servers {
+		if server != nil {
+			server.validate(v, joinPointer(ptr, "servers", strconv.Itoa(i)))
+		}
 	}
-	return validateAll(validaters)
 }
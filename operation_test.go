@@ -13,6 +13,11 @@ func TestOperation_Validate(t *testing.T) {
 		{"empty", openapi.Operation{}, openapi.ErrRequired{Target: "operation.responses"}},
 		{"duplicatedParameter", openapi.Operation{Responses: openapi.Responses{}, Parameters: []*openapi.Parameter{&openapi.Parameter{Name: "foo", In: "query"}, &openapi.Parameter{Name: "foo", In: "query"}}}, openapi.ErrParameterDuplicated},
 		{"valid", openapi.Operation{Responses: openapi.Responses{}}, nil},
+		// the order of the errors
+		{"duplicatedParameter-noResponses", openapi.Operation{Parameters: []*openapi.Parameter{&openapi.Parameter{Name: "foo", In: "query"}, &openapi.Parameter{Name: "foo", In: "query"}}}, openapi.ErrParameterDuplicated},
+		{"invalidExternalDocs-noResponses", openapi.Operation{ExternalDocs: &openapi.ExternalDocumentation{}}, openapi.ErrRequired{Target: "operation.responses"}},
+		{"invalidExternalDocs-invalidParameter", openapi.Operation{Responses: openapi.Responses{}, ExternalDocs: &openapi.ExternalDocumentation{}, Parameters: []*openapi.Parameter{&openapi.Parameter{}}}, openapi.ErrRequired{Target: "externalDocumentation.url"}},
+		{"invalidParameter-invalidRequestBody", openapi.Operation{Responses: openapi.Responses{}, Parameters: []*openapi.Parameter{&openapi.Parameter{}}, RequestBody: &openapi.RequestBody{}}, openapi.ErrRequired{Target: "parameter.name"}},
 	}
 	testValidater(t, candidates)
 }
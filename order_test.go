@@ -0,0 +1,143 @@
+package openapi_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const orderedSpec = `openapi: 3.0.0
+info:
+  title: ordered
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      responses:
+        '201':
+          description: created
+        '400':
+          description: bad request
+        default:
+          description: error
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              responses:
+                '200':
+                  description: ok
+          '{$request.body#/alt}':
+            post:
+              responses:
+                '200':
+                  description: ok
+  /accounts:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+        id:
+          type: integer
+        age:
+          type: integer
+    Account:
+      type: object
+`
+
+func TestDocument_Keys(t *testing.T) {
+	doc, err := openapi.Load([]byte(orderedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/users"].Post
+	candidates := []struct {
+		label string
+		got   []string
+		want  []string
+	}{
+		{"paths", doc.PathKeys(), []string{"/users", "/accounts"}},
+		{"responses", doc.ResponseKeys(op.Responses), []string{"201", "400", "default"}},
+		{"schemas", doc.SchemaKeys(), []string{"User", "Account"}},
+		{"properties", doc.PropertyKeys(doc.Components.Schemas["User"]), []string{"name", "id", "age"}},
+		{"callback", doc.CallbackKeys(*op.Callbacks["onEvent"]), []string{"{$request.body#/url}", "{$request.body#/alt}"}},
+		{"not a map", doc.Keys("foo"), nil},
+	}
+	for _, c := range candidates {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s: %v != %v", c.label, c.got, c.want)
+		}
+	}
+
+	doc.Paths["/b"] = &openapi.PathItem{}
+	doc.Paths["/a"] = &openapi.PathItem{}
+	delete(doc.Paths, "/accounts")
+	want := []string{"/users", "/a", "/b"}
+	if got := doc.PathKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("keys added after loading should be sorted: %v != %v", got, want)
+	}
+}
+
+func TestDocument_KeysExternal(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/external/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Dereference(); err != nil {
+		t.Fatal(err)
+	}
+	pet := doc.Components.Schemas["Pets"].Items
+	want := []string{"id", "name", "category", "tag"}
+	if got := doc.PropertyKeys(pet); !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+}
+
+func TestDocument_WalkInSourceOrder(t *testing.T) {
+	doc, err := openapi.Load([]byte(orderedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	err = doc.WalkInSourceOrder(func(doc *openapi.Document, method, path string, pathItem *openapi.PathItem, op *openapi.Operation) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/users", "/accounts"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("%v != %v", paths, want)
+	}
+}
+
+func TestDocument_MarshalInSourceOrder(t *testing.T) {
+	doc, err := openapi.Load([]byte(orderedSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{"/users:", `"201":`, `"400":`, "default:", "{$request.body#/url}", "{$request.body#/alt}", "/accounts:", "User:", "name:", "id:", "age:", "Account:"}
+	idx := 0
+	for _, key := range keys {
+		i := bytes.Index(b[idx:], []byte(key))
+		if i < 0 {
+			t.Fatalf("%s is not found after %d:\n%s", key, idx, b)
+		}
+		idx += i
+	}
+}
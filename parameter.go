@@ -12,7 +12,7 @@ type Parameter struct {
 	AllowEmptyValue bool `yaml:"allowEmptyValue"`
 
 	Style         string
-	Explode       bool
+	Explode       *bool
 	AllowReserved bool `yaml:"allowReserved"`
 	Schema        *Schema
 	Example       interface{}
@@ -21,30 +21,69 @@ type Parameter struct {
 	Content map[string]*MediaType
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Parameter object.
 // This function DOES NOT check whether the name field correspond to the associated path or not.
+// It is checked by validating the Document.
+// The parameter with $ref is not validated, like Response, as the
+// referenced one is validated in the components of the Document.
 func (parameter Parameter) Validate() error {
+	return validateFirst(parameter)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (parameter Parameter) GetExtension(name string, v interface{}) error {
+	return getExtension(parameter.Extension, name, v)
+}
+
+func (parameter Parameter) validate(v *validator, ptr string) {
+	if parameter.Ref != "" {
+		return // validated in doc.Components
+	}
+	validateExtension(v, ptr, parameter.Extension)
 	if err := parameter.validateRequiredObjects(); err != nil {
-		return err
+		v.report(ptr, err)
+		return
 	}
 	switch parameter.In {
 	case InQuery, InHeader, InPath, InCookie:
 	default:
-		return ErrMustOneOf{Object: "parameter.in", ValidValues: ParameterInList}
+		v.report(joinPointer(ptr, "in"), ErrMustOneOf{Object: "parameter.in", ValidValues: ParameterInList})
 	}
 	if parameter.In == InPath && !parameter.Required {
-		return ErrRequiredMustTrue
+		v.report(joinPointer(ptr, "required"), ErrRequiredMustTrue)
 	}
 	if parameter.In != InQuery && parameter.AllowEmptyValue {
-		return ErrAllowEmptyValueNotValid
+		v.report(joinPointer(ptr, "allowEmptyValue"), ErrAllowEmptyValueNotValid)
 	}
 	if len(parameter.Content) > 1 {
-		return ErrTooManyParameterContent
+		v.report(joinPointer(ptr, "content"), ErrTooManyParameterContent)
 	}
 
-	return validateAll(parameter.reduceValidaters())
+	if parameter.Schema != nil {
+		parameter.Schema.validate(v, joinPointer(ptr, "schema"))
+	}
+	if e, ok := parameter.Example.(pointerValidater); ok {
+		e.validate(v, joinPointer(ptr, "example"))
+	}
+	v.validateExample(parameter.Schema, parameter.Example, joinPointer(ptr, "example"))
+	v.validateExamples(parameter.Schema, parameter.Examples, joinPointer(ptr, "examples"))
+
+	for name, example := range parameter.Examples {
+		if example != nil {
+			example.validate(v, joinPointer(ptr, "examples", name))
+		}
+	}
+
+	for mime, mediaType := range parameter.Content {
+		if mediaType != nil {
+			mediaType.validate(v, joinPointer(ptr, "content", mime))
+		}
+	}
 }
 
 func (parameter Parameter) validateRequiredObjects() error {
@@ -56,20 +95,3 @@ func (parameter Parameter) validateRequiredObjects() error {
 	}
 	return nil
 }
-
-func (parameter Parameter) reduceValidaters() []validater {
-	validaters := []validater{}
-	if parameter.Schema != nil {
-		validaters = append(validaters, parameter.Schema)
-	}
-	if v, ok := parameter.Example.(validater); ok {
-		validaters = append(validaters, v)
-	}
-
-	// example has no validation
-
-	for _, mediaType := range parameter.Content {
-		validaters = append(validaters, mediaType)
-	}
-	return validaters
-}
@@ -0,0 +1,739 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The styles of the parameters.
+const (
+	StyleMatrix         = "matrix"
+	StyleLabel          = "label"
+	StyleForm           = "form"
+	StyleSimple         = "simple"
+	StyleSpaceDelimited = "spaceDelimited"
+	StylePipeDelimited  = "pipeDelimited"
+	StyleDeepObject     = "deepObject"
+)
+
+// DefaultStyle returns the style of the parameter. If the style is
+// not given, it is form for query and cookie parameters, and simple
+// for path and header parameters.
+func (parameter Parameter) DefaultStyle() string {
+	if parameter.Style != "" {
+		return parameter.Style
+	}
+	switch parameter.In {
+	case InQuery, InCookie:
+		return StyleForm
+	}
+	return StyleSimple
+}
+
+// explode returns the value of explode. If it is not given, it is true
+// only for the form style.
+func (parameter Parameter) explode() bool {
+	if parameter.Explode != nil {
+		return *parameter.Explode
+	}
+	return parameter.DefaultStyle() == StyleForm
+}
+
+// paramStyle describes how a value is serialized.
+type paramStyle struct {
+	name          string
+	style         string
+	explode       bool
+	allowReserved bool
+	// escape escapes the names and the values in the serialization,
+	// and unescape reverses it. The values are unescaped after split
+	// by the delimiters, so that the escaped delimiters are kept.
+	escape   func(string) string
+	unescape func(string) (string, error)
+}
+
+func noEscape(s string) string { return s }
+
+func noUnescape(s string) (string, error) { return s, nil }
+
+func (parameter Parameter) paramStyle() paramStyle {
+	st := paramStyle{
+		name:          parameter.Name,
+		style:         parameter.DefaultStyle(),
+		explode:       parameter.explode(),
+		allowReserved: parameter.AllowReserved,
+	}
+	switch parameter.In {
+	case InPath:
+		st.escape, st.unescape = st.pathEscape, url.PathUnescape
+	case InQuery:
+		st.escape, st.unescape = st.queryEscape, url.QueryUnescape
+	default:
+		st.escape, st.unescape = noEscape, noUnescape
+	}
+	return st
+}
+
+// encodingStyle returns the style of the property of the form body
+// described by the encoding object, which can be nil. The values of
+// the URL encoded forms are given escaped.
+func encodingStyle(name string, encoding *Encoding, urlEncoded bool) paramStyle {
+	st := paramStyle{name: name, style: StyleForm, explode: true}
+	if encoding != nil {
+		if encoding.Style != "" {
+			st.style = encoding.Style
+		}
+		if encoding.Explode != nil {
+			st.explode = *encoding.Explode
+		} else {
+			st.explode = st.style == StyleForm
+		}
+		st.allowReserved = encoding.AllowReserved
+	}
+	st.escape, st.unescape = st.queryEscape, noUnescape
+	if urlEncoded {
+		st.unescape = url.QueryUnescape
+	}
+	return st
+}
+
+// headerStyle returns the style of the header object, which is always
+// simple.
+func headerStyle(name string, header *Header) paramStyle {
+	st := paramStyle{name: name, style: StyleSimple, escape: noEscape, unescape: noUnescape}
+	if header.Explode != nil {
+		st.explode = *header.Explode
+	}
+	return st
+}
+
+// pathEscape escapes the string for the path. In addition to
+// url.PathEscape, = and the delimiter . of the exploded label style are
+// escaped, which are the delimiters in the path segment.
+func (st paramStyle) pathEscape(s string) string {
+	s = strings.ReplaceAll(url.PathEscape(s), "=", "%3D")
+	if st.style == StyleLabel && st.explode {
+		s = strings.ReplaceAll(s, ".", "%2E")
+	}
+	return s
+}
+
+// queryEscape escapes the string for the query. If allowReserved is
+// true, the reserved characters are kept as they are.
+func (st paramStyle) queryEscape(s string) string {
+	if !st.allowReserved {
+		return url.QueryEscape(s)
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(":/?#[]@!$&'()*+,;=", r) {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(url.QueryEscape(string(r)))
+	}
+	return b.String()
+}
+
+// DecodeParameter decodes the value of the parameter in the request
+// into the Go value typed by the schema of the parameter, following its
+// style and explode. The primitives are decoded into int64, float64,
+// bool or string, the arrays into []interface{} and the objects into
+// map[string]interface{}. If the parameter has content instead of
+// schema, the value is decoded as JSON for JSON media types, or given
+// as string.
+// pathParams holds the escaped values of the path parameters, like
+// Route.RawPathParams, as the values are split by the delimiters before
+// unescaped. ok is false if the parameter is not in the request.
+func (doc *Document) DecodeParameter(parameter *Parameter, r *http.Request, pathParams map[string]string) (value interface{}, ok bool, err error) {
+	if parameter.Ref != "" {
+		resolved, err := resolveChain(doc, parameter.Ref, parameter)
+		if err != nil {
+			return nil, false, ErrUnresolvedRef{Ref: parameter.Ref, Err: err}
+		}
+		parameter = resolved.(*Parameter)
+	}
+	var values url.Values
+	switch parameter.In {
+	case InPath:
+		if value, ok := pathParams[parameter.Name]; ok {
+			values = url.Values{parameter.Name: {value}}
+		}
+	case InQuery:
+		values, _ = parseRawQuery(r.URL.RawQuery)
+	case InHeader:
+		if header := r.Header.Values(parameter.Name); len(header) > 0 {
+			values = url.Values{parameter.Name: {strings.Join(header, ",")}}
+		}
+	case InCookie:
+		values = url.Values{}
+		for _, cookie := range r.Cookies() {
+			values.Add(cookie.Name, cookie.Value)
+		}
+	}
+	if parameter.Schema == nil {
+		for mt := range parameter.Content {
+			raw, ok := values[parameter.Name]
+			if !ok || len(raw) == 0 {
+				return nil, false, nil
+			}
+			s, err := parameter.paramStyle().unescape(raw[0])
+			if err != nil {
+				return nil, true, ErrValue{Keyword: "style", Reason: err.Error()}
+			}
+			if !isJSONMediaType(mt) {
+				return s, true, nil
+			}
+			return decodeContent(s)
+		}
+	}
+	return doc.decodeStyle(parameter.paramStyle(), parameter.Schema, values)
+}
+
+// parseRawQuery parses the query like url.ParseQuery, but keeps the
+// values escaped. The keys are unescaped. The pairs which cannot be
+// unescaped are skipped, and the first error is returned.
+func parseRawQuery(query string) (url.Values, error) {
+	values := url.Values{}
+	var firstErr error
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value := pair, ""
+		if idx := strings.IndexByte(pair, '='); idx >= 0 {
+			key, value = pair[:idx], pair[idx+1:]
+		}
+		key, err := url.QueryUnescape(key)
+		if err == nil {
+			_, err = url.QueryUnescape(value)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		values[key] = append(values[key], value)
+	}
+	return values, firstErr
+}
+
+func decodeContent(raw string) (interface{}, bool, error) {
+	var value interface{}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, true, ErrValue{Keyword: "content", Reason: "invalid JSON: " + err.Error()}
+	}
+	return value, true, nil
+}
+
+// EncodeParameter serializes the value following the style and
+// explode of the parameter, so that DecodeParameter decodes it back.
+// The delimiters in the values are escaped, except for the header and
+// cookie parameters, and the query parameters allowing reserved
+// characters, which cannot escape them.
+// The result is what is written in the request: the query string like
+// id=3&id=4 for query parameters, the path segment like ;id=3,4 for
+// path parameters, which replaces {id} in the path template, the
+// header value like 3,4 for header parameters, and name=value for
+// cookie parameters. The object properties are serialized in the order
+// of the schema properties.
+func (doc *Document) EncodeParameter(parameter *Parameter, value interface{}) (string, error) {
+	if parameter.Ref != "" {
+		resolved, err := resolveChain(doc, parameter.Ref, parameter)
+		if err != nil {
+			return "", ErrUnresolvedRef{Ref: parameter.Ref, Err: err}
+		}
+		parameter = resolved.(*Parameter)
+	}
+	st := parameter.paramStyle()
+	if parameter.Schema == nil && len(parameter.Content) > 0 {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		if parameter.In == InPath || parameter.In == InHeader {
+			return st.escape(string(b)), nil
+		}
+		return st.escape(st.name) + "=" + st.escape(string(b)), nil
+	}
+	return doc.encodeStyle(st, parameter.Schema, value)
+}
+
+// resolveSchema returns the schema which the reference points. If the
+// reference cannot be resolved, nil is returned. In OAS 3.1, the
+// keywords beside $ref override the ones of the referenced schema.
+func (doc *Document) resolveSchema(schema *Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	resolved, err := resolveChain(doc, schema.Ref, schema)
+	if err != nil {
+		return nil
+	}
+	if doc.IsOAS31() && hasSiblings(schema) {
+		return overrideSchema(resolved.(*Schema), schema)
+	}
+	return resolved.(*Schema)
+}
+
+// overrideSchema returns a copy of the schema whose keywords are
+// overridden by the ones set in siblings, except $ref.
+func overrideSchema(schema, siblings *Schema) *Schema {
+	merged := *schema
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(siblings).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if dst.Type().Field(i).Name == "Ref" || !dst.Field(i).CanSet() || src.Field(i).IsZero() {
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
+	return &merged
+}
+
+// kindOf returns the type of the schema which decides the
+// serialization: array, object or the primitive types.
+func kindOf(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	for _, typ := range schema.TypeNames() {
+		if typ != "null" {
+			return typ
+		}
+	}
+	if len(schema.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+func (doc *Document) decodeStyle(st paramStyle, schema *Schema, values url.Values) (interface{}, bool, error) {
+	schema = doc.resolveSchema(schema)
+	kind := kindOf(schema)
+	switch st.style {
+	case StyleSimple, StyleLabel, StyleMatrix:
+		raw, ok := values[st.name]
+		if !ok || len(raw) == 0 {
+			return nil, false, nil
+		}
+		value, err := doc.decodeString(st, schema, raw[0])
+		return value, true, err
+	case StyleDeepObject:
+		obj := map[string]string{}
+		var keys []string
+		prefix := st.name + "["
+		for key, raw := range values {
+			if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") && len(raw) > 0 {
+				name := key[len(prefix) : len(key)-1]
+				obj[name] = raw[0]
+				keys = append(keys, name)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, false, nil
+		}
+		value, err := doc.decodeObject(st, schema, keys, obj)
+		return value, true, err
+	}
+	// form, spaceDelimited and pipeDelimited
+	if kind == "object" && st.explode {
+		obj := map[string]string{}
+		var keys []string
+		for _, name := range doc.PropertyKeys(schema) {
+			if raw, ok := values[name]; ok && len(raw) > 0 {
+				obj[name] = raw[0]
+				keys = append(keys, name)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, false, nil
+		}
+		value, err := doc.decodeObject(st, schema, keys, obj)
+		return value, true, err
+	}
+	raw, ok := values[st.name]
+	if !ok || len(raw) == 0 {
+		return nil, false, nil
+	}
+	delim := ","
+	switch st.style {
+	case StyleSpaceDelimited:
+		delim = "%20"
+	case StylePipeDelimited:
+		delim = "|"
+	}
+	switch kind {
+	case "array":
+		parts := raw
+		if !st.explode {
+			parts = splitValue(raw[0], delim)
+		}
+		value, err := doc.decodeItems(st, schema, parts)
+		return value, true, err
+	case "object":
+		value, err := doc.decodeAlternating(st, schema, splitValue(raw[0], delim))
+		return value, true, err
+	}
+	value, err := doc.decodePrimitive(st, schema, raw[0])
+	return value, true, err
+}
+
+// decodeString decodes the simple, label or matrix style value.
+func (doc *Document) decodeString(st paramStyle, schema *Schema, s string) (interface{}, error) {
+	delim := ","
+	switch st.style {
+	case StyleLabel:
+		if !strings.HasPrefix(s, ".") {
+			return nil, ErrValue{Keyword: "style", Reason: fmt.Sprintf("%q does not start with .", s)}
+		}
+		s = s[1:]
+		if st.explode {
+			delim = "."
+		}
+	case StyleMatrix:
+		if !strings.HasPrefix(s, ";") {
+			return nil, ErrValue{Keyword: "style", Reason: fmt.Sprintf("%q does not start with ;", s)}
+		}
+		return doc.decodeMatrix(st, schema, strings.Split(s[1:], ";"))
+	}
+	kind := kindOf(schema)
+	switch kind {
+	case "array":
+		return doc.decodeItems(st, schema, splitValue(s, delim))
+	case "object":
+		if st.explode {
+			return doc.decodePairs(st, schema, splitValue(s, delim))
+		}
+		return doc.decodeAlternating(st, schema, splitValue(s, delim))
+	}
+	return doc.decodePrimitive(st, schema, s)
+}
+
+// decodeMatrix decodes the matrix style value, which is split by ;.
+func (doc *Document) decodeMatrix(st paramStyle, schema *Schema, parts []string) (interface{}, error) {
+	if kindOf(schema) == "object" && st.explode {
+		return doc.decodePairs(st, schema, parts)
+	}
+	var values []string
+	for _, part := range parts {
+		name, value := part, ""
+		if idx := strings.IndexByte(part, '='); idx >= 0 {
+			name, value = part[:idx], part[idx+1:]
+		}
+		if unescaped, err := st.unescape(name); err != nil || unescaped != st.name {
+			return nil, ErrValue{Keyword: "style", Reason: fmt.Sprintf("unexpected name %q", name)}
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil, ErrValue{Keyword: "style", Reason: "no value"}
+	}
+	switch kindOf(schema) {
+	case "array":
+		if !st.explode {
+			values = splitValue(values[0], ",")
+		}
+		return doc.decodeItems(st, schema, values)
+	case "object":
+		return doc.decodeAlternating(st, schema, splitValue(values[0], ","))
+	}
+	return doc.decodePrimitive(st, schema, values[0])
+}
+
+// splitValue splits the value by delim. The empty value has no
+// elements.
+func splitValue(s, delim string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, delim)
+}
+
+// unescapeValue unescapes the value split by the delimiters.
+func (st paramStyle) unescapeValue(s string) (string, error) {
+	unescaped, err := st.unescape(s)
+	if err != nil {
+		return "", ErrValue{Keyword: "style", Reason: fmt.Sprintf("%q cannot be unescaped", s)}
+	}
+	return unescaped, nil
+}
+
+// decodePrimitive unescapes the value and converts it into the type of
+// the schema.
+func (doc *Document) decodePrimitive(st paramStyle, schema *Schema, s string) (interface{}, error) {
+	unescaped, err := st.unescapeValue(s)
+	if err != nil {
+		return nil, err
+	}
+	return doc.parsePrimitive(schema, unescaped)
+}
+
+func (doc *Document) decodeItems(st paramStyle, schema *Schema, parts []string) ([]interface{}, error) {
+	items := doc.resolveSchema(schema.Items)
+	values := make([]interface{}, len(parts))
+	for i, part := range parts {
+		value, err := doc.decodePrimitive(st, items, part)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodePairs decodes the object given as name=value pairs.
+func (doc *Document) decodePairs(st paramStyle, schema *Schema, parts []string) (map[string]interface{}, error) {
+	obj := map[string]string{}
+	var keys []string
+	for _, part := range parts {
+		idx := strings.IndexByte(part, '=')
+		if idx < 0 {
+			return nil, ErrValue{Keyword: "style", Reason: fmt.Sprintf("%q is not name=value", part)}
+		}
+		key, err := st.unescapeValue(part[:idx])
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = part[idx+1:]
+		keys = append(keys, key)
+	}
+	return doc.decodeObject(st, schema, keys, obj)
+}
+
+// decodeAlternating decodes the object given as the names and the
+// values alternately.
+func (doc *Document) decodeAlternating(st paramStyle, schema *Schema, parts []string) (map[string]interface{}, error) {
+	if len(parts)%2 != 0 {
+		return nil, ErrValue{Keyword: "style", Reason: "the names and the values are not paired"}
+	}
+	obj := map[string]string{}
+	var keys []string
+	for i := 0; i < len(parts); i += 2 {
+		key, err := st.unescapeValue(parts[i])
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = parts[i+1]
+		keys = append(keys, key)
+	}
+	return doc.decodeObject(st, schema, keys, obj)
+}
+
+// decodeObject decodes the object whose keys are unescaped and whose
+// values are escaped.
+func (doc *Document) decodeObject(st paramStyle, schema *Schema, keys []string, obj map[string]string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(obj))
+	for _, key := range keys {
+		var property *Schema
+		if schema != nil {
+			var ok bool
+			if property, ok = schema.Properties[key]; !ok {
+				property = schema.AdditionalProperties
+			}
+		}
+		value, err := doc.decodePrimitive(st, doc.resolveSchema(property), obj[key])
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// parsePrimitive converts the string into the type of the schema,
+// and checks the format of the value.
+func (doc *Document) parsePrimitive(schema *Schema, s string) (interface{}, error) {
+	var value interface{} = s
+	switch kindOf(schema) {
+	case "integer":
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, ErrValue{Keyword: "type", Reason: fmt.Sprintf("%q is not integer", s)}
+		}
+		value = i
+	case "number":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, ErrValue{Keyword: "type", Reason: fmt.Sprintf("%q is not number", s)}
+		}
+		value = f
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, ErrValue{Keyword: "type", Reason: fmt.Sprintf("%q is not boolean", s)}
+		}
+		value = b
+	}
+	if schema != nil {
+		if err := doc.formatRegistry().Check(schema.Format, value); err != nil {
+			return nil, ErrValue{Keyword: "format", Reason: fmt.Sprintf("%s: %s", schema.Format, err)}
+		}
+	}
+	return value, nil
+}
+
+// encodeStyle serializes the value. The arrays and the objects are
+// given as slices and maps.
+func (doc *Document) encodeStyle(st paramStyle, schema *Schema, value interface{}) (string, error) {
+	schema = doc.resolveSchema(schema)
+	v := reflect.ValueOf(value)
+	var items []string
+	var keys, props []string
+	isArray, isObject := false, false
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		isArray = true
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, st.escape(formatPrimitive(v.Index(i).Interface())))
+		}
+	case reflect.Map:
+		isObject = true
+		for _, key := range doc.sortedKeys(schema, v) {
+			keys = append(keys, st.escape(key))
+			props = append(props, st.escape(formatPrimitive(v.MapIndex(reflect.ValueOf(key)).Interface())))
+		}
+	}
+	name := st.escape(st.name)
+	pairs := func(sep, delim string) string {
+		parts := make([]string, len(keys))
+		for i := range keys {
+			parts[i] = keys[i] + sep + props[i]
+		}
+		return strings.Join(parts, delim)
+	}
+	alternating := func(delim string) string {
+		parts := make([]string, 0, 2*len(keys))
+		for i := range keys {
+			parts = append(parts, keys[i], props[i])
+		}
+		return strings.Join(parts, delim)
+	}
+	switch st.style {
+	case StyleSimple:
+		switch {
+		case isArray:
+			return strings.Join(items, ","), nil
+		case isObject && st.explode:
+			return pairs("=", ","), nil
+		case isObject:
+			return alternating(","), nil
+		}
+		return st.escape(formatPrimitive(value)), nil
+	case StyleLabel:
+		delim := ","
+		if st.explode {
+			delim = "."
+		}
+		switch {
+		case isArray:
+			return "." + strings.Join(items, delim), nil
+		case isObject && st.explode:
+			return "." + pairs("=", delim), nil
+		case isObject:
+			return "." + alternating(delim), nil
+		}
+		return "." + st.escape(formatPrimitive(value)), nil
+	case StyleMatrix:
+		switch {
+		case isArray && st.explode:
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = ";" + name + "=" + item
+			}
+			return strings.Join(parts, ""), nil
+		case isArray:
+			return ";" + name + "=" + strings.Join(items, ","), nil
+		case isObject && st.explode:
+			return ";" + pairs("=", ";"), nil
+		case isObject:
+			return ";" + name + "=" + alternating(","), nil
+		}
+		if s := st.escape(formatPrimitive(value)); s != "" {
+			return ";" + name + "=" + s, nil
+		}
+		return ";" + name, nil
+	case StyleDeepObject:
+		if !isObject {
+			return "", ErrValue{Keyword: "style", Reason: "deepObject style is only for objects"}
+		}
+		parts := make([]string, len(keys))
+		for i := range keys {
+			parts[i] = name + "[" + keys[i] + "]=" + props[i]
+		}
+		return strings.Join(parts, "&"), nil
+	case StyleForm, StyleSpaceDelimited, StylePipeDelimited:
+		delim := ","
+		switch st.style {
+		case StyleSpaceDelimited:
+			delim = "%20"
+		case StylePipeDelimited:
+			delim = "|"
+		}
+		switch {
+		case isArray && st.explode:
+			parts := make([]string, len(items))
+			for i, item := range items {
+				parts[i] = name + "=" + item
+			}
+			return strings.Join(parts, "&"), nil
+		case isArray:
+			return name + "=" + strings.Join(items, delim), nil
+		case isObject && st.explode:
+			return pairs("=", "&"), nil
+		case isObject:
+			return name + "=" + alternating(delim), nil
+		}
+		return name + "=" + st.escape(formatPrimitive(value)), nil
+	}
+	return "", ErrValue{Keyword: "style", Reason: "unknown style " + st.style}
+}
+
+// sortedKeys returns the keys of the map in the order of the schema
+// properties, followed by the other keys in alphabetical order.
+func (doc *Document) sortedKeys(schema *Schema, m reflect.Value) []string {
+	present := map[string]bool{}
+	for _, key := range m.MapKeys() {
+		if key.Kind() == reflect.String {
+			present[key.String()] = true
+		}
+	}
+	var keys []string
+	if schema != nil {
+		for _, name := range doc.PropertyKeys(schema) {
+			if present[name] {
+				keys = append(keys, name)
+				delete(present, name)
+			}
+		}
+	}
+	var rest []string
+	for key := range present {
+		rest = append(rest, key)
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+func formatPrimitive(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	}
+	return fmt.Sprint(value)
+}
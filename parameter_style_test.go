@@ -0,0 +1,316 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const styleSpec = `openapi: 3.0.3
+info:
+  title: style
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    primitive:
+      type: string
+    array:
+      type: array
+      items:
+        type: string
+    object:
+      type: object
+      properties:
+        R:
+          type: integer
+        G:
+          type: integer
+        B:
+          type: integer
+`
+
+func TestDocument_EncodeParameter(t *testing.T) {
+	doc, err := openapi.Load([]byte(styleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	primitive := "blue"
+	array := []interface{}{"blue", "black", "brown"}
+	object := map[string]interface{}{"R": int64(100), "G": int64(200), "B": int64(150)}
+	values := map[string]interface{}{"primitive": primitive, "array": array, "object": object}
+
+	// the examples in the specification
+	candidates := []struct {
+		in       openapi.InType
+		style    string
+		explode  bool
+		kind     string
+		expected string
+	}{
+		{openapi.InPath, openapi.StyleMatrix, false, "primitive", ";color=blue"},
+		{openapi.InPath, openapi.StyleMatrix, false, "array", ";color=blue,black,brown"},
+		{openapi.InPath, openapi.StyleMatrix, false, "object", ";color=R,100,G,200,B,150"},
+		{openapi.InPath, openapi.StyleMatrix, true, "primitive", ";color=blue"},
+		{openapi.InPath, openapi.StyleMatrix, true, "array", ";color=blue;color=black;color=brown"},
+		{openapi.InPath, openapi.StyleMatrix, true, "object", ";R=100;G=200;B=150"},
+		{openapi.InPath, openapi.StyleLabel, false, "primitive", ".blue"},
+		{openapi.InPath, openapi.StyleLabel, false, "array", ".blue,black,brown"},
+		{openapi.InPath, openapi.StyleLabel, false, "object", ".R,100,G,200,B,150"},
+		{openapi.InPath, openapi.StyleLabel, true, "primitive", ".blue"},
+		{openapi.InPath, openapi.StyleLabel, true, "array", ".blue.black.brown"},
+		{openapi.InPath, openapi.StyleLabel, true, "object", ".R=100.G=200.B=150"},
+		{openapi.InPath, openapi.StyleSimple, false, "primitive", "blue"},
+		{openapi.InPath, openapi.StyleSimple, false, "array", "blue,black,brown"},
+		{openapi.InPath, openapi.StyleSimple, false, "object", "R,100,G,200,B,150"},
+		{openapi.InHeader, openapi.StyleSimple, true, "primitive", "blue"},
+		{openapi.InHeader, openapi.StyleSimple, true, "array", "blue,black,brown"},
+		{openapi.InHeader, openapi.StyleSimple, true, "object", "R=100,G=200,B=150"},
+		{openapi.InQuery, openapi.StyleForm, false, "primitive", "color=blue"},
+		{openapi.InQuery, openapi.StyleForm, false, "array", "color=blue,black,brown"},
+		{openapi.InQuery, openapi.StyleForm, false, "object", "color=R,100,G,200,B,150"},
+		{openapi.InQuery, openapi.StyleForm, true, "primitive", "color=blue"},
+		{openapi.InQuery, openapi.StyleForm, true, "array", "color=blue&color=black&color=brown"},
+		{openapi.InQuery, openapi.StyleForm, true, "object", "R=100&G=200&B=150"},
+		{openapi.InQuery, openapi.StyleSpaceDelimited, false, "array", "color=blue%20black%20brown"},
+		{openapi.InQuery, openapi.StyleSpaceDelimited, false, "object", "color=R%20100%20G%20200%20B%20150"},
+		{openapi.InQuery, openapi.StylePipeDelimited, false, "array", "color=blue|black|brown"},
+		{openapi.InQuery, openapi.StylePipeDelimited, false, "object", "color=R|100|G|200|B|150"},
+		{openapi.InQuery, openapi.StyleDeepObject, true, "object", "color[R]=100&color[G]=200&color[B]=150"},
+		{openapi.InCookie, openapi.StyleForm, false, "primitive", "color=blue"},
+		{openapi.InCookie, openapi.StyleForm, false, "array", "color=blue,black,brown"},
+	}
+	for _, c := range candidates {
+		explode := c.explode
+		parameter := &openapi.Parameter{
+			Name:    "color",
+			In:      c.in,
+			Style:   c.style,
+			Explode: &explode,
+			Schema:  &openapi.Schema{Ref: "#/components/schemas/" + c.kind},
+		}
+		label := c.style + "/" + c.kind
+		if c.explode {
+			label += "/explode"
+		}
+		t.Run(label, func(t *testing.T) {
+			got, err := doc.EncodeParameter(parameter, values[c.kind])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.expected {
+				t.Errorf("%s != %s", got, c.expected)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			var pathParams map[string]string
+			switch c.in {
+			case openapi.InPath:
+				pathParams = map[string]string{"color": got}
+			case openapi.InQuery:
+				r = httptest.NewRequest(http.MethodGet, "/?"+got, nil)
+			case openapi.InHeader:
+				r.Header.Set("color", got)
+			case openapi.InCookie:
+				r.Header.Set("Cookie", got)
+			}
+			value, ok, err := doc.DecodeParameter(parameter, r, pathParams)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("parameter is not found")
+			}
+			if !reflect.DeepEqual(value, values[c.kind]) {
+				t.Errorf("%#v != %#v", value, values[c.kind])
+			}
+		})
+	}
+}
+
+func TestDocument_EncodeParameter_Delimiters(t *testing.T) {
+	doc, err := openapi.Load([]byte(styleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	array := []interface{}{"a,b.c;d|e f", "g"}
+	object := map[string]interface{}{"R": int64(1), "a,b.c;d|e=f": "g,h.i;j|k l"}
+	candidates := []struct {
+		in      openapi.InType
+		style   string
+		explode bool
+	}{
+		{openapi.InPath, openapi.StyleSimple, false},
+		{openapi.InPath, openapi.StyleSimple, true},
+		{openapi.InPath, openapi.StyleLabel, false},
+		{openapi.InPath, openapi.StyleLabel, true},
+		{openapi.InPath, openapi.StyleMatrix, false},
+		{openapi.InPath, openapi.StyleMatrix, true},
+		{openapi.InQuery, openapi.StyleForm, false},
+		{openapi.InQuery, openapi.StyleForm, true},
+		{openapi.InQuery, openapi.StyleSpaceDelimited, false},
+		{openapi.InQuery, openapi.StylePipeDelimited, false},
+	}
+	for _, c := range candidates {
+		for kind, value := range map[string]interface{}{"array": array, "object": object} {
+			explode := c.explode
+			parameter := &openapi.Parameter{
+				Name:    "color",
+				In:      c.in,
+				Style:   c.style,
+				Explode: &explode,
+				Schema:  &openapi.Schema{Ref: "#/components/schemas/" + kind},
+			}
+			if kind == "object" {
+				parameter.Schema = &openapi.Schema{
+					Type:                 "object",
+					Properties:           map[string]*openapi.Schema{"R": {Type: "integer"}},
+					AdditionalProperties: &openapi.Schema{Type: "string"},
+				}
+				if c.in == openapi.InQuery && c.explode {
+					continue // the additional properties cannot be found
+				}
+			}
+			label := c.style + "/" + kind
+			if c.explode {
+				label += "/explode"
+			}
+			t.Run(label, func(t *testing.T) {
+				got, err := doc.EncodeParameter(parameter, value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				var pathParams map[string]string
+				if c.in == openapi.InPath {
+					pathParams = map[string]string{"color": got}
+				} else {
+					r = httptest.NewRequest(http.MethodGet, "/?"+got, nil)
+				}
+				decoded, ok, err := doc.DecodeParameter(parameter, r, pathParams)
+				if err != nil || !ok {
+					t.Fatalf("%s: unexpected result: %v, %v", got, ok, err)
+				}
+				if !reflect.DeepEqual(decoded, value) {
+					t.Errorf("%s: %#v != %#v", got, decoded, value)
+				}
+			})
+		}
+	}
+}
+
+func TestDocument_DecodeParameter(t *testing.T) {
+	doc, err := openapi.Load([]byte(styleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("default style", func(t *testing.T) {
+		parameter := &openapi.Parameter{
+			Name:   "id",
+			In:     openapi.InQuery,
+			Schema: &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "integer"}},
+		}
+		r := httptest.NewRequest(http.MethodGet, "/?id=3&id=4", nil)
+		value, ok, err := doc.DecodeParameter(parameter, r, nil)
+		if err != nil || !ok {
+			t.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+		if want := []interface{}{int64(3), int64(4)}; !reflect.DeepEqual(value, want) {
+			t.Errorf("%#v != %#v", value, want)
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		parameter := &openapi.Parameter{Name: "id", In: openapi.InQuery, Schema: &openapi.Schema{Type: "integer"}}
+		r := httptest.NewRequest(http.MethodGet, "/?other=1", nil)
+		if _, ok, err := doc.DecodeParameter(parameter, r, nil); ok || err != nil {
+			t.Errorf("unexpected result: %v, %v", ok, err)
+		}
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		parameter := &openapi.Parameter{Name: "id", In: openapi.InPath, Schema: &openapi.Schema{Type: "integer"}}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		_, ok, err := doc.DecodeParameter(parameter, r, map[string]string{"id": "abc"})
+		if _, isValueErr := err.(openapi.ErrValue); !ok || !isValueErr {
+			t.Errorf("unexpected result: %v, %v", ok, err)
+		}
+	})
+	t.Run("invalid matrix", func(t *testing.T) {
+		parameter := &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix, Schema: &openapi.Schema{Type: "integer"}}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if _, _, err := doc.DecodeParameter(parameter, r, map[string]string{"id": ";other=1"}); err == nil {
+			t.Error("error should be returned")
+		}
+	})
+	t.Run("content", func(t *testing.T) {
+		parameter := &openapi.Parameter{
+			Name:    "filter",
+			In:      openapi.InQuery,
+			Content: map[string]*openapi.MediaType{"application/json": {}},
+		}
+		r := httptest.NewRequest(http.MethodGet, "/?filter="+url.QueryEscape(`{"name":"kitty"}`), nil)
+		value, ok, err := doc.DecodeParameter(parameter, r, nil)
+		if err != nil || !ok {
+			t.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+		if want := map[string]interface{}{"name": "kitty"}; !reflect.DeepEqual(value, want) {
+			t.Errorf("%#v != %#v", value, want)
+		}
+	})
+}
+
+func TestDocument_EncodeParameter_AllowReserved(t *testing.T) {
+	doc, err := openapi.Load([]byte(styleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameter := &openapi.Parameter{Name: "path", In: openapi.InQuery, Schema: &openapi.Schema{Type: "string"}}
+	got, err := doc.EncodeParameter(parameter, "/a b,c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "path=%2Fa+b%2Cc" {
+		t.Errorf("unexpected result: %s", got)
+	}
+	parameter.AllowReserved = true
+	got, err = doc.EncodeParameter(parameter, "/a b,c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "path=/a+b,c" {
+		t.Errorf("unexpected result: %s", got)
+	}
+}
+
+func TestDocument_DecodeParameter_RefSiblings(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: siblings
+  version: 1.0.0
+components:
+  schemas:
+    list:
+      type: array
+      items:
+        type: string
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameter := &openapi.Parameter{
+		Name:   "id",
+		In:     openapi.InQuery,
+		Schema: &openapi.Schema{Ref: "#/components/schemas/list", Items: &openapi.Schema{Type: "integer"}},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/?id=3&id=4", nil)
+	value, ok, err := doc.DecodeParameter(parameter, r, nil)
+	if err != nil || !ok {
+		t.Fatalf("unexpected result: %v, %v", ok, err)
+	}
+	if want := []interface{}{int64(3), int64(4)}; !reflect.DeepEqual(value, want) {
+		t.Errorf("%#v != %#v", value, want)
+	}
+}
@@ -42,6 +42,7 @@ func TestParameter_Validate(t *testing.T) {
 		{"withName-inPath-required", openapi.Parameter{Name: "foo", In: "path", Required: true}, nil},
 		{"allowEmptyValue-notQuery", openapi.Parameter{Name: "foo", In: "header", AllowEmptyValue: true}, openapi.ErrAllowEmptyValueNotValid},
 		{"allowEmptyValue-query", openapi.Parameter{Name: "foo", In: "query", AllowEmptyValue: true}, nil},
+		{"reference", openapi.Parameter{Ref: "#/components/parameters/foo"}, nil},
 	}
 	testValidater(t, candidates)
 }
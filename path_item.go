@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -23,6 +24,8 @@ type PathItem struct {
 	Trace       *Operation
 	Servers     []*Server
 	Parameters  []*Parameter
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 var methods = []string{
@@ -92,20 +95,36 @@ func (pathItem PathItem) Operations() map[string]*Operation {
 
 // Validate the values of PathItem object.
 func (pathItem PathItem) Validate() error {
-	validaters := []validater{}
-	for _, op := range pathItem.Operations() {
-		validaters = append(validaters, op)
+	return validateFirst(pathItem)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (pathItem PathItem) GetExtension(name string, v interface{}) error {
+	return getExtension(pathItem.Extension, name, v)
+}
+
+func (pathItem PathItem) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, pathItem.Extension)
+	for _, method := range methods {
+		if op := pathItem.GetOperationByMethod(method); op != nil {
+			op.validate(v, joinPointer(ptr, strings.ToLower(method)))
+		}
 	}
-	for _, s := range pathItem.Servers {
-		validaters = append(validaters, s)
+	for i, s := range pathItem.Servers {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "servers", strconv.Itoa(i)))
+		}
 	}
 	if hasDuplicatedParameter(pathItem.Parameters) {
-		return ErrParameterDuplicated
+		v.report(joinPointer(ptr, "parameters"), ErrParameterDuplicated)
+		return
 	}
-	for _, p := range pathItem.Parameters {
-		validaters = append(validaters, p)
+	for i, p := range pathItem.Parameters {
+		if p != nil {
+			p.validate(v, joinPointer(ptr, "parameters", strconv.Itoa(i)))
+		}
 	}
-	return validateAll(validaters)
 }
 
 func hasDuplicatedParameter(parameters []*Parameter) bool {
@@ -11,21 +11,53 @@ type Paths map[string]*PathItem
 
 // Validate the values of Paths object.
 func (paths Paths) Validate() error {
+	return validateFirst(paths)
+}
+
+func (paths Paths) validate(v *validator, ptr string) {
 	for path, pathItem := range paths {
 		if !strings.HasPrefix(path, "/") {
-			return ErrPathFormat
+			v.report(joinPointer(ptr, path), ErrPathFormat)
+		}
+		if _, ok := pathTemplateVariables(path); !ok {
+			v.report(joinPointer(ptr, path), ErrPathTemplateFormat)
 		}
-		if err := pathItem.Validate(); err != nil {
-			return err
+		if pathItem != nil {
+			pathItem.validate(v, joinPointer(ptr, path))
 		}
 	}
 	if paths.hasDuplicatedOperationID() {
-		return ErrOperationIDDuplicated
+		v.report(ptr, ErrOperationIDDuplicated)
 	}
 	if paths.hasDuplicatedPaths() {
-		return ErrPathsDuplicated
+		v.report(ptr, ErrPathsDuplicated)
 	}
-	return nil
+}
+
+// pathTemplateVariables returns the names of the template variables in
+// the path. ok is false if the braces are not balanced or a variable
+// has no name.
+func pathTemplateVariables(path string) (names []string, ok bool) {
+	start := -1
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '{':
+			if start >= 0 {
+				return nil, false
+			}
+			start = i + 1
+		case '}':
+			if start < 0 || start == i {
+				return nil, false
+			}
+			names = append(names, path[start:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		return nil, false
+	}
+	return names, true
 }
 
 func (paths Paths) hasDuplicatedOperationID() bool {
@@ -53,6 +53,15 @@ func TestPaths_Validate(t *testing.T) {
 			},
 			openapi.ErrPathsDuplicated,
 		},
+		{
+			"malformed template",
+			openapi.Paths{
+				"/foo/{bar": &openapi.PathItem{
+					Get: &openapi.Operation{OperationID: "foo", Responses: openapi.Responses{"200": &openapi.Response{Description: "foo"}}},
+				},
+			},
+			openapi.ErrPathTemplateFormat,
+		},
 	}
 	testValidater(t, candidates)
 }
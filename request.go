@@ -0,0 +1,355 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxBodyBytes is the default limit of the size of the request
+// bodies which RequestValidator reads.
+const DefaultMaxBodyBytes = 10 << 20
+
+// RequestValidatorOptions is the options for RequestValidator.
+type RequestValidatorOptions struct {
+	// MaxBodyBytes limits the size of the request body. The requests
+	// whose body is larger are rejected with 413 Request Entity Too
+	// Large. If zero, DefaultMaxBodyBytes is used, and if negative,
+	// the size is not limited.
+	MaxBodyBytes int64
+}
+
+func (opts RequestValidatorOptions) maxBodyBytes() int64 {
+	if opts.MaxBodyBytes == 0 {
+		return DefaultMaxBodyBytes
+	}
+	return opts.MaxBodyBytes
+}
+
+// RequestValidator returns a middleware which validates the requests
+// against the document before passing them to the next handler.
+// The invalid requests are rejected with 400 Bad Request and a
+// problem details (RFC 7807) holding all the errors. The requests
+// which match no operation are rejected with 404 Not Found or 405
+// Method Not Allowed, and the ones whose body is too large are
+// rejected with 413 Request Entity Too Large.
+func RequestValidator(doc *Document, opts RequestValidatorOptions) func(http.Handler) http.Handler {
+	router := NewRouter(doc)
+	limit := opts.maxBodyBytes()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limit > 0 && r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			route, err := router.FindRequest(r)
+			if err == nil {
+				err = doc.validateRequest(route, r, limit)
+			}
+			if err != nil {
+				status := http.StatusBadRequest
+				switch err {
+				case ErrPathNotFound:
+					status = http.StatusNotFound
+				case ErrMethodNotAllowed:
+					status = http.StatusMethodNotAllowed
+					w.Header().Set("Allow", strings.Join(route.AllowedMethods(), ", "))
+				case ErrRequestBodyTooLarge:
+					status = http.StatusRequestEntityTooLarge
+				}
+				writeProblem(w, status, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ValidateRequest validates the HTTP request against the operation
+// which matches it. The parameters and the request body are checked
+// against their schemas, and the errors are returned as ErrorList,
+// whose pointers point to the invalid parts of the request, like
+// /query/limit, /header/X-Request-ID or /body/name.
+// If no operation matches the request, ErrPathNotFound or
+// ErrMethodNotAllowed is returned.
+// The request body is read and replaced, so that it can be read again.
+// To validate many requests, use RequestValidator, which compiles the
+// router only once.
+func (doc *Document) ValidateRequest(r *http.Request) error {
+	route, err := NewRouter(doc).FindRequest(r)
+	if err != nil {
+		return err
+	}
+	return doc.validateRequest(route, r, 0)
+}
+
+// validateRequest validates the request for the route. If limit is
+// positive, the request body must be limited by http.MaxBytesReader
+// with it.
+func (doc *Document) validateRequest(route *Route, r *http.Request, limit int64) error {
+	op := route.Operation
+	vv := newValueValidator(doc)
+	vv.context = requestContext
+	parameters, err := doc.mergeParameters(route.PathItem, op)
+	if err != nil {
+		vv.v.report("", err)
+	}
+	for _, parameter := range parameters {
+		vv.validateParameter(r, parameter, route.RawPathParams)
+	}
+	if op.RequestBody != nil {
+		body, err := readBody(r, limit)
+		if err != nil {
+			return err
+		}
+		vv.validateRequestBody(r, op.RequestBody, body)
+	}
+	return vv.v.err()
+}
+
+// readBody reads the request body and replaces it with the read one.
+// If the body is limited to limit bytes and it is exceeded,
+// ErrRequestBodyTooLarge is returned.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		if limit > 0 && int64(len(b)) >= limit {
+			return nil, ErrRequestBodyTooLarge
+		}
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// validateParameter checks the parameter in the request.
+func (vv *valueValidator) validateParameter(r *http.Request, parameter *Parameter, pathParams map[string]string) {
+	ptr := joinPointer("", string(parameter.In), parameter.Name)
+	value, ok, err := vv.doc.DecodeParameter(parameter, r, pathParams)
+	switch {
+	case err != nil:
+		vv.v.report(ptr, err)
+		return
+	case !ok:
+		if parameter.Required {
+			vv.report(ptr, "required", "%s parameter %s is required", parameter.In, parameter.Name)
+		}
+		return
+	}
+	schema := parameter.Schema
+	if schema == nil {
+		for _, mediaType := range parameter.Content {
+			schema = mediaType.Schema
+		}
+	}
+	vv.validate(schema, value, ptr)
+}
+
+// validateRequestBody checks the request body against the schema of
+// the media type of the request.
+func (vv *valueValidator) validateRequestBody(r *http.Request, requestBody *RequestBody, body []byte) {
+	if requestBody.Ref != "" {
+		resolved, err := resolveChain(vv.doc, requestBody.Ref, requestBody)
+		if err != nil {
+			vv.v.report("/body", ErrUnresolvedRef{Ref: requestBody.Ref, Err: err})
+			return
+		}
+		requestBody = resolved.(*RequestBody)
+	}
+	if len(body) == 0 {
+		if requestBody.Required {
+			vv.report("/body", "required", "request body is required")
+		}
+		return
+	}
+	vv.validateBody(r.Header.Get("Content-Type"), requestBody.Content, body, "/body")
+}
+
+// validateBody checks the body against the schema of the media type
+// given by contentType.
+func (vv *valueValidator) validateBody(contentType string, content map[string]*MediaType, body []byte, ptr string) {
+	contentTypePtr := joinPointer("", "header", "Content-Type")
+	mt, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		vv.report(contentTypePtr, "content", "invalid media type %q", contentType)
+		return
+	}
+	mediaType := matchMediaType(content, mt)
+	if mediaType == nil {
+		vv.report(contentTypePtr, "content", "media type %s is not supported", mt)
+		return
+	}
+	if mediaType.Schema == nil {
+		return
+	}
+	var value interface{}
+	switch {
+	case isJSONMediaType(mt):
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&value); err != nil {
+			vv.report(ptr, "content", "invalid JSON: %s", err)
+			return
+		}
+	case mt == "application/x-www-form-urlencoded":
+		form, err := parseRawQuery(string(body))
+		if err != nil {
+			vv.report(ptr, "content", "invalid form: %s", err)
+			return
+		}
+		value = vv.parseForm(mediaType, form, true, ptr)
+	case mt == "multipart/form-data":
+		form, err := parseMultipart(body, params["boundary"])
+		if err != nil {
+			vv.report(ptr, "content", "invalid multipart: %s", err)
+			return
+		}
+		value = vv.parseForm(mediaType, form, false, ptr)
+	default:
+		return // cannot be decoded
+	}
+	vv.validate(mediaType.Schema, value, ptr)
+}
+
+// parseForm converts the form values into an object. The properties
+// are decoded by their schemas, following the styles in the encoding
+// objects of the media type. The values of the URL encoded form are
+// given escaped.
+func (vv *valueValidator) parseForm(mediaType *MediaType, form url.Values, urlEncoded bool, ptr string) map[string]interface{} {
+	schema := vv.doc.resolveSchema(mediaType.Schema)
+	obj := map[string]interface{}{}
+	for name, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		obj[name] = values[0]
+		if urlEncoded {
+			obj[name], _ = url.QueryUnescape(values[0]) // checked by parseRawQuery
+		}
+	}
+	if schema == nil {
+		return obj
+	}
+	for _, name := range vv.doc.PropertyKeys(schema) {
+		st := encodingStyle(name, mediaType.Encoding[name], urlEncoded)
+		value, ok, err := vv.doc.decodeStyle(st, schema.Properties[name], form)
+		switch {
+		case err != nil:
+			vv.v.report(joinPointer(ptr, name), err)
+			delete(obj, name)
+		case ok:
+			obj[name] = value
+		}
+	}
+	return obj
+}
+
+// parseMultipart parses the multipart body into the values. The files
+// are given as their contents.
+func parseMultipart(body []byte, boundary string) (url.Values, error) {
+	if boundary == "" {
+		return nil, errors.New("no boundary")
+	}
+	r := multipart.NewReader(bytes.NewReader(body), boundary)
+	form := url.Values{}
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		form.Add(part.FormName(), string(b))
+	}
+}
+
+// matchMediaType returns the media type object for the media type,
+// preferring the exact one to the ranges like text/* and */*.
+func matchMediaType(content map[string]*MediaType, mt string) *MediaType {
+	if mediaType, ok := content[mt]; ok {
+		return mediaType
+	}
+	for key, mediaType := range content {
+		if strings.EqualFold(key, mt) {
+			return mediaType
+		}
+	}
+	if idx := strings.IndexByte(mt, '/'); idx >= 0 {
+		if mediaType, ok := content[mt[:idx]+"/*"]; ok {
+			return mediaType
+		}
+	}
+	return content["*/*"]
+}
+
+// isJSONMediaType reports whether the media type is JSON, like
+// application/json or application/problem+json.
+func isJSONMediaType(mt string) bool {
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+// Problem is a problem details object (RFC 7807), which is written by
+// the middlewares when they reject the requests.
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is an error in the problem details, with the JSON
+// pointer to the invalid part of the request.
+type ProblemError struct {
+	Pointer string `json:"pointer"`
+	Detail  string `json:"detail"`
+}
+
+// newProblem returns the problem details with the status for the
+// validation error.
+func newProblem(status int, err error) Problem {
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	var errs ErrorList
+	if !errors.As(err, &errs) {
+		problem.Detail = err.Error()
+		return problem
+	}
+	problem.Detail = "the request does not match the API specification"
+	if status >= 500 {
+		problem.Detail = "the response does not match the API specification"
+	}
+	for _, e := range errs {
+		problem.Errors = append(problem.Errors, ProblemError{Pointer: e.Pointer, Detail: e.Err.Error()})
+	}
+	return problem
+}
+
+func writeProblem(w http.ResponseWriter, status int, err error) {
+	problem := newProblem(status, err)
+	b, err := json.Marshal(problem)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	w.Write(b)
+}
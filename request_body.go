@@ -9,20 +9,32 @@ type RequestBody struct {
 	Required    bool
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of RequestBody object.
 func (requestBody RequestBody) Validate() error {
+	return validateFirst(requestBody)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (requestBody RequestBody) GetExtension(name string, v interface{}) error {
+	return getExtension(requestBody.Extension, name, v)
+}
+
+func (requestBody RequestBody) validate(v *validator, ptr string) {
 	if requestBody.Ref != "" {
-		return nil // validated in doc.Components
+		return // validated in doc.Components
 	}
+	validateExtension(v, ptr, requestBody.Extension)
 	if requestBody.Content == nil || len(requestBody.Content) == 0 {
-		return ErrRequired{Target: "requestBody.content"}
+		v.report(ptr, ErrRequired{Target: "requestBody.content"})
 	}
-	for _, mediaType := range requestBody.Content {
-		if err := mediaType.Validate(); err != nil {
-			return err
+	for mime, mediaType := range requestBody.Content {
+		if mediaType != nil {
+			mediaType.validate(v, joinPointer(ptr, "content", mime))
 		}
 	}
-	return nil
 }
@@ -0,0 +1,321 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const requestSpec = `openapi: 3.0.3
+info:
+  title: request
+  version: 1.0.0
+servers:
+  - url: https://example.com/{base}
+    variables:
+      base:
+        default: v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [dog, cat]
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+            pattern: '^[0-9a-f]+$'
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            minLength: 4
+      responses:
+        '200':
+          description: ok
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+      responses:
+        '201':
+          description: created
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 1
+    get:
+      responses:
+        '200':
+          description: ok
+  /pets/mine:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        maximum: 100
+  requestBodies:
+    Pet:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+        application/x-www-form-urlencoded:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+          minimum: 1
+`
+
+func errorPointers(t *testing.T, err error) []string {
+	t.Helper()
+	var errs openapi.ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ptrs []string
+	for _, e := range errs {
+		ptrs = append(ptrs, e.Pointer)
+	}
+	sort.Strings(ptrs)
+	return ptrs
+}
+
+func TestDocument_ValidateRequest(t *testing.T) {
+	doc, err := openapi.Load([]byte(requestSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		label       string
+		method      string
+		target      string
+		header      http.Header
+		body        string
+		expected    []string
+		expectedErr error
+	}{
+		{
+			label:  "valid query",
+			method: http.MethodGet,
+			target: "/v1/pets?limit=10&tags=dog&tags=cat",
+			header: http.Header{"X-Request-Id": {"abc123"}, "Cookie": {"session=abcd"}},
+		},
+		{
+			label:    "invalid parameters",
+			method:   http.MethodGet,
+			target:   "/v1/pets?limit=1000&tags=dog&tags=bird",
+			header:   http.Header{"Cookie": {"session=abc"}},
+			expected: []string{"/cookie/session", "/header/X-Request-ID", "/query/limit", "/query/tags/1"},
+		},
+		{
+			label:    "not integer",
+			method:   http.MethodGet,
+			target:   "/v1/pets?limit=ten",
+			header:   http.Header{"X-Request-Id": {"abc123"}},
+			expected: []string{"/query/limit"},
+		},
+		{
+			label:  "path parameter",
+			method: http.MethodGet,
+			target: "/v1/pets/3",
+		},
+		{
+			label:    "invalid path parameter",
+			method:   http.MethodGet,
+			target:   "/v1/pets/0",
+			expected: []string{"/path/petId"},
+		},
+		{
+			label:  "concrete path",
+			method: http.MethodGet,
+			target: "/v1/pets/mine",
+		},
+		{
+			label:  "valid JSON body",
+			method: http.MethodPost,
+			target: "/v1/pets",
+			header: http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			body:   `{"name": "kitty", "age": 2}`,
+		},
+		{
+			label:    "invalid JSON body",
+			method:   http.MethodPost,
+			target:   "/v1/pets",
+			header:   http.Header{"Content-Type": {"application/json"}},
+			body:     `{"age": 0}`,
+			expected: []string{"/body", "/body/age"},
+		},
+		{
+			label:    "invalid form body",
+			method:   http.MethodPost,
+			target:   "/v1/pets",
+			header:   http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+			body:     `name=kitty&age=0`,
+			expected: []string{"/body/age"},
+		},
+		{
+			label:    "missing body",
+			method:   http.MethodPost,
+			target:   "/v1/pets",
+			expected: []string{"/body"},
+		},
+		{
+			label:    "unsupported media type",
+			method:   http.MethodPost,
+			target:   "/v1/pets",
+			header:   http.Header{"Content-Type": {"text/plain"}},
+			body:     `kitty`,
+			expected: []string{"/header/Content-Type"},
+		},
+		{
+			label:       "not found",
+			method:      http.MethodGet,
+			target:      "/pets",
+			expectedErr: openapi.ErrPathNotFound,
+		},
+		{
+			label:       "method not allowed",
+			method:      http.MethodDelete,
+			target:      "/v1/pets",
+			expectedErr: openapi.ErrMethodNotAllowed,
+		},
+	}
+	for _, c := range candidates {
+		t.Run(c.label, func(t *testing.T) {
+			r := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+			for k, v := range c.header {
+				r.Header[k] = v
+			}
+			err := doc.ValidateRequest(r)
+			switch {
+			case c.expectedErr != nil:
+				if err != c.expectedErr {
+					t.Errorf("%v != %v", err, c.expectedErr)
+				}
+			case c.expected == nil:
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			default:
+				if got := errorPointers(t, err); !reflect.DeepEqual(got, c.expected) {
+					t.Errorf("%v != %v", got, c.expected)
+				}
+			}
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != c.body {
+				t.Errorf("body should be readable again: %q", b)
+			}
+		})
+	}
+}
+
+func TestRequestValidator(t *testing.T) {
+	doc, err := openapi.Load([]byte(requestSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := openapi.RequestValidator(doc, openapi.RequestValidatorOptions{MaxBodyBytes: 32})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(b)
+	}))
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"name":"kitty"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusCreated {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+		if w.Body.String() != `{"name":"kitty"}` {
+			t.Errorf("unexpected body: %s", w.Body)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"name":1}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("unexpected content type: %s", ct)
+		}
+		var problem openapi.Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Status != http.StatusBadRequest || len(problem.Errors) != 1 || problem.Errors[0].Pointer != "/body/name" {
+			t.Errorf("unexpected problem: %+v", problem)
+		}
+	})
+	t.Run("too large", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"name":"`+strings.Repeat("a", 32)+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+	})
+	t.Run("method not allowed", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/v1/pets", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+		if allow := w.Header().Get("Allow"); allow != "GET, POST" {
+			t.Errorf("unexpected Allow header: %s", allow)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/dogs", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+	})
+}
@@ -2,28 +2,54 @@ package openapi
 
 import (
 	"errors"
+	"net/url"
+	"reflect"
 	"strings"
 )
 
 // ErrTypeAssertion is raised when the type assertion error is occurred.
 var ErrTypeAssertion = errors.New("type assertion error")
 
-func resolve(root *Document, ref string) (interface{}, error) {
+// resolve the object referenced by ref. hint is a nil pointer of the
+// type of the referenced object, which is used to decode the object in
+// other documents.
+func resolve(root *Document, ref string, hint interface{}) (interface{}, error) {
 	switch {
+	case ref == "#":
+		return root, nil
 	case strings.HasPrefix(ref, "#/"):
 		path := strings.Split(ref, "/")
 		if len(path) < 2 {
 			return nil, errors.New("ref string length invalid")
 		}
 		return root.resolve(path[1:])
-	default:
+	case strings.HasPrefix(ref, "#"):
+		return nil, ErrFormatInvalid{Target: "reference", Format: "JSON pointer"}
+	case root.loader == nil:
 		return nil, errors.New("cannot resolve relative document")
 	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, ErrFormatInvalid{Target: "reference", Format: "URI"}
+	}
+	abs, err := resolveLocation(root.location, u)
+	if err != nil {
+		return nil, err
+	}
+	location := *abs
+	location.Fragment = ""
+	if location.String() == root.location.String() {
+		return resolve(root, "#"+abs.Fragment, hint)
+	}
+	return root.loader.resolve(abs, reflect.TypeOf(hint).Elem())
 }
 
 func (doc *Document) resolve(path []string) (interface{}, error) {
 	switch s := path[0]; s {
 	case "components":
+		if doc.Components == nil {
+			return nil, errors.New("components is not defined")
+		}
 		return doc.Components.resolve(path[1:])
 	default:
 		return nil, errors.New("unknown reference path: " + s)
@@ -56,6 +82,8 @@ func (components *Components) resolve(path []string) (interface{}, error) {
 		ret, ok = components.Links[next]
 	case "callbacks":
 		ret, ok = components.Callbacks[next]
+	case "pathItems":
+		ret, ok = components.PathItems[next]
 	default:
 		return nil, errors.New("unknown reference path: " + s)
 	}
@@ -67,7 +95,7 @@ func (components *Components) resolve(path []string) (interface{}, error) {
 
 // ResolveSchema resolves a schema reference string.
 func ResolveSchema(root *Document, ref string) (*Schema, error) {
-	si, err := resolve(root, ref)
+	si, err := resolve(root, ref, (*Schema)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +107,7 @@ func ResolveSchema(root *Document, ref string) (*Schema, error) {
 
 // ResolveResponse resolves a response reference string.
 func ResolveResponse(root *Document, ref string) (*Response, error) {
-	ri, err := resolve(root, ref)
+	ri, err := resolve(root, ref, (*Response)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +119,7 @@ func ResolveResponse(root *Document, ref string) (*Response, error) {
 
 // ResolveParameter resolves a response reference string.
 func ResolveParameter(root *Document, ref string) (*Parameter, error) {
-	pi, err := resolve(root, ref)
+	pi, err := resolve(root, ref, (*Parameter)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +131,7 @@ func ResolveParameter(root *Document, ref string) (*Parameter, error) {
 
 // ResolveExample resolves an example reference string.
 func ResolveExample(root *Document, ref string) (*Example, error) {
-	ei, err := resolve(root, ref)
+	ei, err := resolve(root, ref, (*Example)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +143,7 @@ func ResolveExample(root *Document, ref string) (*Example, error) {
 
 // ResolveRequestBody resolves a requestBody reference string.
 func ResolveRequestBody(root *Document, ref string) (*RequestBody, error) {
-	ri, err := resolve(root, ref)
+	ri, err := resolve(root, ref, (*RequestBody)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +155,7 @@ func ResolveRequestBody(root *Document, ref string) (*RequestBody, error) {
 
 // ResolveHeader resolves a header reference string.
 func ResolveHeader(root *Document, ref string) (*Header, error) {
-	hi, err := resolve(root, ref)
+	hi, err := resolve(root, ref, (*Header)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +167,7 @@ func ResolveHeader(root *Document, ref string) (*Header, error) {
 
 // ResolveSecurityScheme resolves a securityScheme reference string.
 func ResolveSecurityScheme(root *Document, ref string) (*SecurityScheme, error) {
-	si, err := resolve(root, ref)
+	si, err := resolve(root, ref, (*SecurityScheme)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +179,7 @@ func ResolveSecurityScheme(root *Document, ref string) (*SecurityScheme, error)
 
 // ResolveLink resolves a link reference string.
 func ResolveLink(root *Document, ref string) (*Link, error) {
-	li, err := resolve(root, ref)
+	li, err := resolve(root, ref, (*Link)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +191,7 @@ func ResolveLink(root *Document, ref string) (*Link, error) {
 
 // ResolveCallback resolves a callback reference string.
 func ResolveCallback(root *Document, ref string) (*Callback, error) {
-	ci, err := resolve(root, ref)
+	ci, err := resolve(root, ref, (*Callback)(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -172,3 +200,15 @@ func ResolveCallback(root *Document, ref string) (*Callback, error) {
 	}
 	return nil, ErrTypeAssertion
 }
+
+// ResolvePathItem resolves a pathItem reference string.
+func ResolvePathItem(root *Document, ref string) (*PathItem, error) {
+	pi, err := resolve(root, ref, (*PathItem)(nil))
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := pi.(*PathItem); ok {
+		return p, nil
+	}
+	return nil, ErrTypeAssertion
+}
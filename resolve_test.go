@@ -118,7 +118,7 @@ func TestResolveExample(t *testing.T) {
 		t.Errorf("%s != a simple example", example.Summary)
 		return
 	}
-	expected := map[interface{}]interface{}{"foo": "bar"}
+	expected := map[string]interface{}{"foo": "bar"}
 	if !reflect.DeepEqual(example.Value, expected) {
 		t.Errorf("%+v != %+v", example.Value, expected)
 		return
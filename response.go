@@ -10,25 +10,42 @@ type Response struct {
 	Links       map[string]*Link
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the value of Response object.
 func (response Response) Validate() error {
+	return validateFirst(response)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (response Response) GetExtension(name string, v interface{}) error {
+	return getExtension(response.Extension, name, v)
+}
+
+func (response Response) validate(v *validator, ptr string) {
 	if response.Ref != "" {
-		return nil // validated in doc.Components
+		return // validated in doc.Components
 	}
+	validateExtension(v, ptr, response.Extension)
 	if response.Description == "" {
-		return ErrRequired{Target: "response.description"}
+		v.report(ptr, ErrRequired{Target: "response.description"})
 	}
-	validaters := []validater{}
-	for _, header := range response.Headers {
-		validaters = append(validaters, header)
+	for name, header := range response.Headers {
+		if header != nil {
+			header.validate(v, joinPointer(ptr, "headers", name))
+		}
 	}
-	for _, mediaType := range response.Content {
-		validaters = append(validaters, mediaType)
+	for mime, mediaType := range response.Content {
+		if mediaType != nil {
+			mediaType.validate(v, joinPointer(ptr, "content", mime))
+		}
 	}
-	for _, link := range response.Links {
-		validaters = append(validaters, link)
+	for name, link := range response.Links {
+		if link != nil {
+			link.validate(v, joinPointer(ptr, "links", name))
+		}
 	}
-	return validateAll(validaters)
 }
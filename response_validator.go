@@ -0,0 +1,246 @@
+package openapi
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidateResponse validates the HTTP response of the operation. The
+// response object is chosen by the status code, in the order of the
+// exact code, the range like 2XX and default. The headers and the body
+// are checked against the response object, and the errors are
+// returned as ErrorList, whose pointers point to the invalid parts of
+// the response, like /status, /header/X-Rate-Limit or /body/name.
+func (doc *Document) ValidateResponse(op *Operation, status int, header http.Header, body []byte) error {
+	vv := newValueValidator(doc)
+	vv.context = responseContext
+	response := doc.responseFor(op, status)
+	if response == nil {
+		vv.report("/status", "status", "status %d is not documented", status)
+		return vv.v.err()
+	}
+	if response.Ref != "" {
+		resolved, err := resolveChain(doc, response.Ref, response)
+		if err != nil {
+			vv.v.report("/status", ErrUnresolvedRef{Ref: response.Ref, Err: err})
+			return vv.v.err()
+		}
+		response = resolved.(*Response)
+	}
+	for _, name := range doc.Keys(response.Headers) {
+		vv.validateHeader(header, name, response.Headers[name])
+	}
+	switch {
+	case len(response.Content) == 0:
+		if len(body) > 0 {
+			vv.report("/body", "content", "response body is not documented")
+		}
+	case len(body) > 0:
+		vv.validateBody(header.Get("Content-Type"), response.Content, body, "/body")
+	}
+	return vv.v.err()
+}
+
+// responseFor returns the response object for the status code.
+func (doc *Document) responseFor(op *Operation, status int) *Response {
+	if op == nil {
+		return nil
+	}
+	code := strconv.Itoa(status)
+	if response, ok := op.Responses[code]; ok {
+		return response
+	}
+	for key, response := range op.Responses {
+		if len(key) == 3 && key[0] == code[0] && strings.EqualFold(key[1:], "XX") {
+			return response
+		}
+	}
+	return op.Responses["default"]
+}
+
+// validateHeader checks the response header. Content-Type is ignored
+// as the specification says.
+func (vv *valueValidator) validateHeader(header http.Header, name string, h *Header) {
+	if h == nil || strings.EqualFold(name, "Content-Type") {
+		return
+	}
+	if h.Ref != "" {
+		resolved, err := resolveChain(vv.doc, h.Ref, h)
+		if err != nil {
+			vv.v.report(joinPointer("", "header", name), ErrUnresolvedRef{Ref: h.Ref, Err: err})
+			return
+		}
+		h = resolved.(*Header)
+	}
+	ptr := joinPointer("", "header", name)
+	values := header.Values(name)
+	if len(values) == 0 {
+		if h.Required {
+			vv.report(ptr, "required", "header %s is required", name)
+		}
+		return
+	}
+	raw := strings.Join(values, ",")
+	if h.Schema == nil {
+		for mt, mediaType := range h.Content {
+			var value interface{} = raw
+			if isJSONMediaType(mt) {
+				var err error
+				if value, _, err = decodeContent(raw); err != nil {
+					vv.v.report(ptr, err)
+					return
+				}
+			}
+			vv.validate(mediaType.Schema, value, ptr)
+		}
+		return
+	}
+	value, _, err := vv.doc.decodeStyle(headerStyle(name, h), h.Schema, url.Values{name: {raw}})
+	if err != nil {
+		vv.v.report(ptr, err)
+		return
+	}
+	vv.validate(h.Schema, value, ptr)
+}
+
+// ResponseValidatorOptions is the options for ResponseValidator.
+type ResponseValidatorOptions struct {
+	// LogOnly makes the responses written to the client as they are,
+	// only reporting the errors. Otherwise, the responses are buffered
+	// and the invalid ones are replaced with 500 Internal Server Error.
+	LogOnly bool
+
+	// MaxBodyBytes limits the size of the response body captured for
+	// the validation in LogOnly mode. The body of the larger responses
+	// is not validated. If zero, DefaultMaxBodyBytes is used, and if
+	// negative, the size is not limited.
+	MaxBodyBytes int64
+
+	// OnError is called with the request and the error when the
+	// response is invalid, or the request matches no operation.
+	// If nil, the error is logged with the log package.
+	OnError func(r *http.Request, err error)
+}
+
+// ResponseValidator returns a middleware which validates the responses
+// written by the next handler against the document. It can be used in
+// the integration tests to detect the handlers drifting from the
+// document, with OnError failing the test.
+// The requests which match no operation are passed to the next handler
+// as they are, after reported to OnError.
+// The responses are flushed only in LogOnly mode, as they are buffered
+// otherwise.
+func ResponseValidator(doc *Document, opts ResponseValidatorOptions) func(http.Handler) http.Handler {
+	onError := opts.OnError
+	if onError == nil {
+		onError = func(r *http.Request, err error) {
+			log.Printf("%s %s: invalid response: %v", r.Method, r.URL.Path, err)
+		}
+	}
+	limit := int64(-1)
+	if opts.LogOnly {
+		limit = RequestValidatorOptions{MaxBodyBytes: opts.MaxBodyBytes}.maxBodyBytes()
+	}
+	router := NewRouter(doc)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, err := router.FindRequest(r)
+			if err != nil {
+				onError(r, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			rec := &responseRecorder{w: w, header: http.Header{}, passThrough: opts.LogOnly, limit: limit}
+			if opts.LogOnly {
+				rec.header = w.Header()
+			}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+			body := rec.body.Bytes()
+			if rec.truncated {
+				body = nil // too large to validate
+			}
+			if err := doc.ValidateResponse(route.Operation, rec.status, rec.header, body); err != nil {
+				onError(r, err)
+				if !opts.LogOnly {
+					writeProblem(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+			if opts.LogOnly {
+				return
+			}
+			for k, v := range rec.header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// responseRecorder records the response written by the handler. If
+// passThrough is true, the response is also written to w. If limit is
+// not negative, the body is recorded up to limit bytes, and truncated
+// is set if it is exceeded.
+type responseRecorder struct {
+	w           http.ResponseWriter
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	passThrough bool
+	limit       int64
+	truncated   bool
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status != 0 {
+		return
+	}
+	rec.status = status
+	if rec.passThrough {
+		rec.w.WriteHeader(status)
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+	switch {
+	case rec.truncated:
+	case rec.limit >= 0 && int64(rec.body.Len()+len(b)) > rec.limit:
+		rec.truncated = true
+		rec.body.Reset()
+	default:
+		rec.body.Write(b)
+	}
+	if rec.passThrough {
+		return rec.w.Write(b)
+	}
+	return len(b), nil
+}
+
+// Flush implements http.Flusher. The response is flushed only if it is
+// passed through.
+func (rec *responseRecorder) Flush() {
+	if !rec.passThrough {
+		return
+	}
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if f, ok := rec.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
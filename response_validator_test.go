@@ -0,0 +1,293 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const responseSpec = `openapi: 3.0.3
+info:
+  title: response
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: ok
+          headers:
+            X-Rate-Limit:
+              $ref: '#/components/headers/RateLimit'
+            Content-Type:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+        '204':
+          description: no content
+        2XX:
+          description: success
+          content:
+            text/plain:
+              schema:
+                type: string
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  headers:
+    RateLimit:
+      required: true
+      schema:
+        type: integer
+        maximum: 100
+  responses:
+    Error:
+      description: error
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            required: [title]
+`
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	doc, err := openapi.Load([]byte(responseSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths["/pets/{petId}"].Get
+	candidates := []struct {
+		label    string
+		status   int
+		header   http.Header
+		body     string
+		expected []string
+	}{
+		{
+			label:  "valid",
+			status: http.StatusOK,
+			header: http.Header{"Content-Type": {"application/json"}, "X-Rate-Limit": {"10"}},
+			body:   `{"name": "kitty"}`,
+		},
+		{
+			label:    "invalid header and body",
+			status:   http.StatusOK,
+			header:   http.Header{"Content-Type": {"application/json"}, "X-Rate-Limit": {"1000"}},
+			body:     `{"name": 1}`,
+			expected: []string{"/body/name", "/header/X-Rate-Limit"},
+		},
+		{
+			label:    "missing header",
+			status:   http.StatusOK,
+			header:   http.Header{"Content-Type": {"application/json"}},
+			body:     `{"name": "kitty"}`,
+			expected: []string{"/header/X-Rate-Limit"},
+		},
+		{
+			label:    "undocumented content type",
+			status:   http.StatusOK,
+			header:   http.Header{"Content-Type": {"text/plain"}, "X-Rate-Limit": {"10"}},
+			body:     `kitty`,
+			expected: []string{"/header/Content-Type"},
+		},
+		{
+			label:  "exact code",
+			status: http.StatusNoContent,
+		},
+		{
+			label:    "undocumented body",
+			status:   http.StatusNoContent,
+			body:     `kitty`,
+			expected: []string{"/body"},
+		},
+		{
+			label:  "range",
+			status: http.StatusCreated,
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   `created`,
+		},
+		{
+			label:  "default",
+			status: http.StatusNotFound,
+			header: http.Header{"Content-Type": {"application/problem+json"}},
+			body:   `{"title": "Not Found"}`,
+		},
+		{
+			label:    "invalid default",
+			status:   http.StatusNotFound,
+			header:   http.Header{"Content-Type": {"application/problem+json"}},
+			body:     `{}`,
+			expected: []string{"/body"},
+		},
+	}
+	for _, c := range candidates {
+		t.Run(c.label, func(t *testing.T) {
+			err := doc.ValidateResponse(op, c.status, c.header, []byte(c.body))
+			if c.expected == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := errorPointers(t, err); !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("%v != %v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestDocument_ValidateResponse_NotDocumented(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: response
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateResponse(doc.Paths["/pets"].Get, http.StatusNotFound, nil, nil)
+	if got := errorPointers(t, err); !reflect.DeepEqual(got, []string{"/status"}) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResponseValidator(t *testing.T) {
+	doc, err := openapi.Load([]byte(responseSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Rate-Limit", "10")
+		if r.URL.Path == "/pets/1" {
+			w.Write([]byte(`{"name": "kitty"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var errs []error
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{
+			OnError: func(r *http.Request, err error) { errs = append(errs, err) },
+		})
+		w := httptest.NewRecorder()
+		mw(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+		if errs != nil {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		if w.Code != http.StatusOK || w.Body.String() != `{"name": "kitty"}` || w.Header().Get("X-Rate-Limit") != "10" {
+			t.Errorf("unexpected response: %d %v %s", w.Code, w.Header(), w.Body)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var errs []error
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{
+			OnError: func(r *http.Request, err error) { errs = append(errs, err) },
+		})
+		w := httptest.NewRecorder()
+		mw(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/2", nil))
+		if len(errs) != 1 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		var errList openapi.ErrorList
+		if !errors.As(errs[0], &errList) || errList[0].Pointer != "/body" {
+			t.Errorf("unexpected error: %v", errs[0])
+		}
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("unexpected status: %d", w.Code)
+		}
+		var problem openapi.Problem
+		if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem.Status != http.StatusInternalServerError || len(problem.Errors) != 1 {
+			t.Errorf("unexpected problem: %+v", problem)
+		}
+	})
+	t.Run("log only", func(t *testing.T) {
+		var errs []error
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{
+			LogOnly: true,
+			OnError: func(r *http.Request, err error) { errs = append(errs, err) },
+		})
+		w := httptest.NewRecorder()
+		mw(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/2", nil))
+		if len(errs) != 1 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		if w.Code != http.StatusOK || w.Body.String() != `{}` {
+			t.Errorf("response should be written as it is: %d %s", w.Code, w.Body)
+		}
+	})
+	t.Run("log only too large", func(t *testing.T) {
+		var errs []error
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{
+			LogOnly:      true,
+			MaxBodyBytes: 1,
+			OnError:      func(r *http.Request, err error) { errs = append(errs, err) },
+		})
+		w := httptest.NewRecorder()
+		mw(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/2", nil))
+		if errs != nil {
+			t.Errorf("body should not be validated: %v", errs)
+		}
+		if w.Body.String() != `{}` {
+			t.Errorf("response should be written as it is: %s", w.Body)
+		}
+	})
+	t.Run("flush", func(t *testing.T) {
+		flusher := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Rate-Limit", "10")
+			w.Write([]byte(`{"name": `))
+			w.(http.Flusher).Flush()
+			w.Write([]byte(`"kitty"}`))
+		})
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{LogOnly: true})
+		w := httptest.NewRecorder()
+		mw(flusher).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+		if !w.Flushed {
+			t.Error("response should be flushed")
+		}
+	})
+	t.Run("no operation", func(t *testing.T) {
+		var errs []error
+		mw := openapi.ResponseValidator(doc, openapi.ResponseValidatorOptions{
+			OnError: func(r *http.Request, err error) { errs = append(errs, err) },
+		})
+		w := httptest.NewRecorder()
+		mw(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dogs", nil))
+		if len(errs) != 1 || !errors.Is(errs[0], openapi.ErrPathNotFound) {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+		if w.Code != http.StatusOK || w.Body.String() != `{}` {
+			t.Errorf("request should be passed through: %d %s", w.Code, w.Body)
+		}
+	})
+}
@@ -11,15 +11,18 @@ type Responses map[string]*Response
 
 // Validate the values of Responses object.
 func (responses Responses) Validate() error {
+	return validateFirst(responses)
+}
+
+func (responses Responses) validate(v *validator, ptr string) {
 	for status, response := range responses {
 		if err := validateStatusCode(status); err != nil {
-			return err
+			v.report(joinPointer(ptr, status), err)
 		}
-		if err := response.Validate(); err != nil {
-			return err
+		if response != nil {
+			response.validate(v, joinPointer(ptr, status))
 		}
 	}
-	return nil
 }
 
 func validateStatusCode(statusStr string) error {
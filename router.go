@@ -0,0 +1,404 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Route is the result of routing a request with Router.
+type Route struct {
+	// Path is the path template in the document, like /pets/{petId}.
+	Path string
+	// PathItem is the path item of the path. If the path item in the
+	// document is a reference, the referenced one is set.
+	PathItem *PathItem
+	// Operation is nil if the path item has no operation for the
+	// method.
+	Operation *Operation
+	// PathParams holds the unescaped values of the path template
+	// variables.
+	PathParams map[string]string
+	// RawPathParams holds the escaped values of the path template
+	// variables, as they are in the request path, which are given to
+	// Document.DecodeParameter.
+	RawPathParams map[string]string
+	// Server is the server whose base path matches the request. It is
+	// nil if the document has no servers.
+	Server *Server
+	// ServerVariables holds the values of the server variables in the
+	// base path.
+	ServerVariables map[string]string
+}
+
+// AllowedMethods returns the methods which the path item of the route
+// has the operations for.
+func (route Route) AllowedMethods() []string {
+	if route.PathItem == nil {
+		return nil
+	}
+	var allowed []string
+	for _, method := range methods {
+		if route.PathItem.GetOperationByMethod(method) != nil {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// Router finds the operations in a document for the requests. The
+// path templates are compiled into a tree when the router is created,
+// so routing a request costs only walking its path segments.
+type Router struct {
+	bases []routeBase
+	root  *routeNode
+}
+
+// NewRouter compiles the path templates and the server URLs of the
+// document into a router. The paths are matched after the base path
+// of the servers, whose variables match their enum values or any
+// segment. If the document has no servers, the paths are matched from
+// the root. The referenced path items are resolved, and the paths
+// whose references cannot be resolved are not routed.
+func NewRouter(doc *Document) *Router {
+	router := &Router{root: &routeNode{}}
+	for _, server := range doc.Servers {
+		if server == nil {
+			continue
+		}
+		if base, ok := compileServer(server); ok {
+			router.bases = append(router.bases, base)
+		}
+	}
+	if len(router.bases) == 0 {
+		router.bases = append(router.bases, routeBase{})
+	}
+	for _, path := range doc.PathKeys() {
+		pathItem := doc.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		if pathItem.Ref != "" {
+			resolved, err := resolveChain(doc, pathItem.Ref, pathItem)
+			if err != nil {
+				continue
+			}
+			pathItem = resolved.(*PathItem)
+		}
+		router.add(path, pathItem)
+	}
+	return router
+}
+
+// FindRequest finds the route for the request. See Find.
+func (router *Router) FindRequest(r *http.Request) (*Route, error) {
+	return router.Find(r.Method, r.URL.EscapedPath())
+}
+
+// Find finds the route for the method and the escaped path. The
+// concrete segments are preferred to the templated ones, so /pets/mine
+// matches /pets/mine rather than /pets/{petId}.
+// If no path matches, ErrPathNotFound is returned. If the path matches
+// but the path item has no operation for the method, the route without
+// the operation is returned with ErrMethodNotAllowed.
+func (router *Router) Find(method, path string) (*Route, error) {
+	segments := splitPath(path)
+	for _, base := range router.bases {
+		rest, serverVars, ok := base.match(segments)
+		if !ok {
+			continue
+		}
+		node, values := router.root.match(rest, nil)
+		if node == nil {
+			continue
+		}
+		route := &Route{
+			Path:            node.path,
+			PathItem:        node.pathItem,
+			Operation:       node.pathItem.GetOperationByMethod(method),
+			PathParams:      make(map[string]string, len(node.names)),
+			RawPathParams:   make(map[string]string, len(node.names)),
+			Server:          base.server,
+			ServerVariables: serverVars,
+		}
+		for i, name := range node.names {
+			route.PathParams[name], _ = url.PathUnescape(values[i]) // checked on matching
+			route.RawPathParams[name] = values[i]
+		}
+		if route.Operation == nil {
+			return route, ErrMethodNotAllowed
+		}
+		return route, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// routeSegment matches a templated segment of the path. If pattern is
+// nil, the whole segment is a variable.
+type routeSegment struct {
+	names   []string
+	pattern *regexp.Regexp
+}
+
+// compileSegment compiles a segment of the path template. ok is false
+// if the segment has no template variables.
+func compileSegment(s string) (seg routeSegment, ok bool) {
+	locs := tmplVarRegexp.FindAllStringIndex(s, -1)
+	if len(locs) == 0 {
+		return routeSegment{}, false
+	}
+	for _, loc := range locs {
+		seg.names = append(seg.names, s[loc[0]+1:loc[1]-1])
+	}
+	if len(locs) == 1 && locs[0][0] == 0 && locs[0][1] == len(s) {
+		return seg, true
+	}
+	var b strings.Builder
+	b.WriteByte('^')
+	last := 0
+	for _, loc := range locs {
+		b.WriteString(regexp.QuoteMeta(s[last:loc[0]]))
+		b.WriteString("(.+?)")
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(s[last:]))
+	b.WriteByte('$')
+	seg.pattern = regexp.MustCompile(b.String())
+	return seg, true
+}
+
+// match matches the escaped path segment, appending the escaped
+// values of the variables.
+func (seg routeSegment) match(s string, values []string) ([]string, bool) {
+	if seg.pattern == nil {
+		return appendValue(values, s)
+	}
+	m := seg.pattern.FindStringSubmatch(s)
+	if m == nil {
+		return values, false
+	}
+	for _, raw := range m[1:] {
+		var ok bool
+		if values, ok = appendValue(values, raw); !ok {
+			return values, false
+		}
+	}
+	return values, true
+}
+
+// appendValue appends the escaped value of the variable, which must
+// be non-empty and can be unescaped.
+func appendValue(values []string, raw string) ([]string, bool) {
+	if raw == "" {
+		return values, false
+	}
+	if _, err := url.PathUnescape(raw); err != nil {
+		return values, false
+	}
+	return append(values, raw), true
+}
+
+// unescapeSegment returns the unescaped segment to be compared with
+// the literal segments of the templates.
+func unescapeSegment(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
+
+// routeBase is the base path of a server.
+type routeBase struct {
+	server   *Server
+	segments []baseSegment
+}
+
+type baseSegment struct {
+	literal string
+	routeSegment
+	// enums holds the allowed values of the server variables.
+	enums map[string][]string
+}
+
+// compileServer compiles the base path of the server URL.
+func compileServer(server *Server) (routeBase, bool) {
+	// the variables are replaced to parse the URL, then restored
+	var vars []string
+	rawurl := tmplVarRegexp.ReplaceAllStringFunc(server.URL, func(tmpl string) string {
+		vars = append(vars, tmpl)
+		return placeholder(len(vars) - 1)
+	})
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return routeBase{}, false
+	}
+	path := u.EscapedPath()
+	for i, tmpl := range vars {
+		path = strings.Replace(path, placeholder(i), tmpl, 1)
+	}
+	base := routeBase{server: server}
+	for _, s := range splitPath(path) {
+		seg, ok := compileSegment(s)
+		if !ok {
+			base.segments = append(base.segments, baseSegment{literal: s})
+			continue
+		}
+		bs := baseSegment{routeSegment: seg, enums: map[string][]string{}}
+		for _, name := range seg.names {
+			if sv, ok := server.Variables[name]; ok && sv != nil && len(sv.Enum) > 0 {
+				bs.enums[name] = sv.Enum
+			}
+		}
+		base.segments = append(base.segments, bs)
+	}
+	return base, true
+}
+
+func placeholder(i int) string {
+	return "openapi-server-variable-" + strconv.Itoa(i) + "-"
+}
+
+// match matches the leading segments with the base path, returning
+// the rest of the segments and the values of the server variables.
+func (base routeBase) match(segments []string) (rest []string, vars map[string]string, ok bool) {
+	if len(base.segments) > len(segments) {
+		return nil, nil, false
+	}
+	var values []string
+	for i, seg := range base.segments {
+		if seg.names == nil {
+			if seg.literal != unescapeSegment(segments[i]) {
+				return nil, nil, false
+			}
+			continue
+		}
+		n := len(values)
+		if values, ok = seg.match(segments[i], values); !ok {
+			return nil, nil, false
+		}
+		for j, name := range seg.names {
+			value, _ := url.PathUnescape(values[n+j]) // checked on matching
+			if enum, ok := seg.enums[name]; ok && !containsString(enum, value) {
+				return nil, nil, false
+			}
+			if vars == nil {
+				vars = map[string]string{}
+			}
+			vars[name] = value
+		}
+	}
+	return segments[len(base.segments):], vars, true
+}
+
+// routeNode is a node of the path tree. The children for the literal
+// segments are tried first, then the templated ones in order. The
+// nodes are shared by the templates which differ only in the names of
+// the variables, so the names are kept in the leaves.
+type routeNode struct {
+	static    map[string]*routeNode
+	templated []*templatedNode
+
+	path     string
+	pathItem *PathItem
+	names    []string
+}
+
+type templatedNode struct {
+	// key is the segment whose variable names are removed.
+	key     string
+	segment routeSegment
+	node    *routeNode
+}
+
+func (router *Router) add(path string, pathItem *PathItem) {
+	node := router.root
+	var names []string
+	for _, s := range splitPath(path) {
+		seg, ok := compileSegment(s)
+		if !ok {
+			node = node.staticChild(s)
+			continue
+		}
+		names = append(names, seg.names...)
+		node = node.templatedChild(s, seg)
+	}
+	if node.pathItem == nil {
+		node.path, node.pathItem, node.names = path, pathItem, names
+	}
+}
+
+func (node *routeNode) staticChild(s string) *routeNode {
+	if node.static == nil {
+		node.static = map[string]*routeNode{}
+	}
+	child, ok := node.static[s]
+	if !ok {
+		child = &routeNode{}
+		node.static[s] = child
+	}
+	return child
+}
+
+// templatedChild returns the child for the templated segment, creating
+// it if needed. The segments like {id}.json are tried before the ones
+// which are a variable as a whole, as they are more concrete.
+func (node *routeNode) templatedChild(s string, seg routeSegment) *routeNode {
+	key := tmplVarRegexp.ReplaceAllLiteralString(s, "{}")
+	for _, t := range node.templated {
+		if t.key == key {
+			return t.node
+		}
+	}
+	child := &templatedNode{key: key, segment: seg, node: &routeNode{}}
+	i := len(node.templated)
+	if seg.pattern != nil {
+		i = 0
+		for i < len(node.templated) && node.templated[i].segment.pattern != nil {
+			i++
+		}
+	}
+	node.templated = append(node.templated, nil)
+	copy(node.templated[i+1:], node.templated[i:])
+	node.templated[i] = child
+	return child.node
+}
+
+// match finds the leaf matching the segments, appending the values of
+// the variables.
+func (node *routeNode) match(segments []string, values []string) (*routeNode, []string) {
+	if len(segments) == 0 {
+		if node.pathItem == nil {
+			return nil, nil
+		}
+		return node, values
+	}
+	s := segments[0]
+	if child, ok := node.static[unescapeSegment(s)]; ok {
+		if leaf, v := child.match(segments[1:], values); leaf != nil {
+			return leaf, v
+		}
+	}
+	for _, t := range node.templated {
+		v, ok := t.segment.match(s, values)
+		if !ok {
+			continue
+		}
+		if leaf, v := t.node.match(segments[1:], v); leaf != nil {
+			return leaf, v
+		}
+	}
+	return nil, nil
+}
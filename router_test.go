@@ -0,0 +1,321 @@
+package openapi_test
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const routerSpec = `openapi: 3.0.3
+info:
+  title: router
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/{version}/api
+    variables:
+      region:
+        default: us
+      version:
+        default: v1
+        enum: [v1, v2]
+  - url: /legacy
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+    post:
+      responses:
+        '201':
+          description: created
+  /pets/{petId}:
+    get:
+      responses:
+        '200':
+          description: ok
+    delete:
+      responses:
+        '204':
+          description: deleted
+  /pets/mine:
+    get:
+      responses:
+        '200':
+          description: ok
+  /pets/{id}/toys/{toyId}:
+    get:
+      responses:
+        '200':
+          description: ok
+  /pets/{petId}.{format}:
+    get:
+      responses:
+        '200':
+          description: ok
+  /users/{userId}/pets/mine:
+    get:
+      responses:
+        '200':
+          description: ok
+  /users/{userId}/{resource}/{resourceId}:
+    get:
+      responses:
+        '200':
+          description: ok
+`
+
+func TestRouter_Find(t *testing.T) {
+	doc, err := openapi.Load([]byte(routerSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := openapi.NewRouter(doc)
+	candidates := []struct {
+		label           string
+		method          string
+		path            string
+		expected        string
+		pathParams      map[string]string
+		serverVariables map[string]string
+		expectedErr     error
+	}{
+		{
+			label:           "literal",
+			method:          http.MethodGet,
+			path:            "/v1/api/pets",
+			expected:        "/pets",
+			pathParams:      map[string]string{},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:           "templated",
+			method:          http.MethodDelete,
+			path:            "/v2/api/pets/42",
+			expected:        "/pets/{petId}",
+			pathParams:      map[string]string{"petId": "42"},
+			serverVariables: map[string]string{"version": "v2"},
+		},
+		{
+			label:           "escaped",
+			method:          http.MethodGet,
+			path:            "/v1/api/pets/a%2Fb",
+			expected:        "/pets/{petId}",
+			pathParams:      map[string]string{"petId": "a/b"},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:           "concrete segment",
+			method:          http.MethodGet,
+			path:            "/v1/api/pets/mine",
+			expected:        "/pets/mine",
+			pathParams:      map[string]string{},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:           "partially templated segment",
+			method:          http.MethodGet,
+			path:            "/v1/api/pets/42.json",
+			expected:        "/pets/{petId}.{format}",
+			pathParams:      map[string]string{"petId": "42", "format": "json"},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:           "shared node with different names",
+			method:          http.MethodGet,
+			path:            "/v1/api/pets/42/toys/7",
+			expected:        "/pets/{id}/toys/{toyId}",
+			pathParams:      map[string]string{"id": "42", "toyId": "7"},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:           "backtracking",
+			method:          http.MethodGet,
+			path:            "/v1/api/users/1/pets/2",
+			expected:        "/users/{userId}/{resource}/{resourceId}",
+			pathParams:      map[string]string{"userId": "1", "resource": "pets", "resourceId": "2"},
+			serverVariables: map[string]string{"version": "v1"},
+		},
+		{
+			label:      "other server",
+			method:     http.MethodGet,
+			path:       "/legacy/users/1/pets/mine",
+			expected:   "/users/{userId}/pets/mine",
+			pathParams: map[string]string{"userId": "1"},
+		},
+		{
+			label:       "server variable not in enum",
+			method:      http.MethodGet,
+			path:        "/v3/api/pets",
+			expectedErr: openapi.ErrPathNotFound,
+		},
+		{
+			label:       "no base path",
+			method:      http.MethodGet,
+			path:        "/pets",
+			expectedErr: openapi.ErrPathNotFound,
+		},
+		{
+			label:       "too long",
+			method:      http.MethodGet,
+			path:        "/v1/api/pets/42/toys",
+			expectedErr: openapi.ErrPathNotFound,
+		},
+		{
+			label:           "method not allowed",
+			method:          http.MethodPut,
+			path:            "/v1/api/pets/42",
+			expected:        "/pets/{petId}",
+			pathParams:      map[string]string{"petId": "42"},
+			serverVariables: map[string]string{"version": "v1"},
+			expectedErr:     openapi.ErrMethodNotAllowed,
+		},
+	}
+	for _, c := range candidates {
+		t.Run(c.label, func(t *testing.T) {
+			route, err := router.Find(c.method, c.path)
+			if err != c.expectedErr {
+				t.Fatalf("%v != %v", err, c.expectedErr)
+			}
+			if c.expected == "" {
+				return
+			}
+			if route.Path != c.expected {
+				t.Errorf("%s != %s", route.Path, c.expected)
+			}
+			if route.PathItem != doc.Paths[c.expected] {
+				t.Errorf("unexpected path item: %+v", route.PathItem)
+			}
+			if c.expectedErr == nil && route.Operation != route.PathItem.GetOperationByMethod(c.method) {
+				t.Errorf("unexpected operation: %+v", route.Operation)
+			}
+			if !reflect.DeepEqual(route.PathParams, c.pathParams) {
+				t.Errorf("%v != %v", route.PathParams, c.pathParams)
+			}
+			if !reflect.DeepEqual(route.ServerVariables, c.serverVariables) {
+				t.Errorf("%v != %v", route.ServerVariables, c.serverVariables)
+			}
+		})
+	}
+}
+
+func TestRouter_Find_RawPathParams(t *testing.T) {
+	doc, err := openapi.Load([]byte(routerSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, err := openapi.NewRouter(doc).Find(http.MethodGet, "/v1/api/pets/a%2Cb,c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"petId": "a,b,c"}; !reflect.DeepEqual(route.PathParams, want) {
+		t.Errorf("%v != %v", route.PathParams, want)
+	}
+	if want := map[string]string{"petId": "a%2Cb,c"}; !reflect.DeepEqual(route.RawPathParams, want) {
+		t.Errorf("%v != %v", route.RawPathParams, want)
+	}
+}
+
+func TestRouter_Find_NoServers(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: router
+  version: 1.0.0
+paths:
+  /:
+    get:
+      responses:
+        '200':
+          description: ok
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := openapi.NewRouter(doc)
+	for path, expected := range map[string]string{"/": "/", "/pets/": "/pets"} {
+		route, err := router.Find(http.MethodGet, path)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if route.Path != expected {
+			t.Errorf("%s: unexpected route: %+v", path, route)
+		}
+	}
+}
+
+func TestRoute_AllowedMethods(t *testing.T) {
+	doc, err := openapi.Load([]byte(routerSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, err := openapi.NewRouter(doc).Find(http.MethodPatch, "/legacy/pets")
+	if err != openapi.ErrMethodNotAllowed {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := route.AllowedMethods(); !reflect.DeepEqual(got, []string{http.MethodGet, http.MethodPost}) {
+		t.Errorf("unexpected methods: %v", got)
+	}
+}
+
+func BenchmarkRouter_Find(b *testing.B) {
+	doc, err := openapi.Load([]byte(routerSpec))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		doc.Paths[fmt.Sprintf("/resources%d/{id}", i)] = &openapi.PathItem{Get: &openapi.Operation{}}
+	}
+	router := openapi.NewRouter(doc)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := router.Find(http.MethodGet, "/v1/api/pets/42/toys/7"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRouter_Find_ReferencedPathItem(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: router
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    $ref: '#/components/pathItems/Pet'
+components:
+  pathItems:
+    Pet:
+      get:
+        responses:
+          '200':
+            description: ok
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := openapi.NewRouter(doc)
+	route, err := router.Find(http.MethodGet, "/pets/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := doc.Components.PathItems["Pet"]; route.PathItem != want || route.Operation != want.Get {
+		t.Errorf("unexpected route: %+v", route)
+	}
+	route, err = router.Find(http.MethodPost, "/pets/1")
+	if err != openapi.ErrMethodNotAllowed {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := route.AllowedMethods(); !reflect.DeepEqual(got, []string{http.MethodGet}) {
+		t.Errorf("unexpected methods: %v", got)
+	}
+}
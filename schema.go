@@ -1,8 +1,7 @@
 package openapi
 
 import (
-	"fmt"
-	"strings"
+	"strconv"
 )
 
 // codebeat:disable[TOO_MANY_IVARS]
@@ -10,20 +9,21 @@ import (
 // Schema Object
 type Schema struct {
 	Title            string
-	MultipleOf       int `yaml:"multipleOf"`
-	Maximum          int
+	MultipleOf       *float64 `yaml:"multipleOf"`
+	Maximum          *float64
 	ExclusiveMaximum bool `yaml:"exclusiveMaximum"`
-	Minimum          int
+	Minimum          *float64
 	ExclusiveMinimum bool `yaml:"exclusiveMinimum"`
-	MaxLength        int  `yaml:"maxLength"`
-	MinLength        int  `yaml:"minLength"`
+	MaxLength        *int `yaml:"maxLength"`
+	MinLength        *int `yaml:"minLength"`
 	Pattern          string
-	MaxItems         int `yaml:"maxItems"`
-	MinItems         int `yaml:"minItems"`
-	MaxProperties    int `yaml:"maxProperties"`
-	MinProperties    int `yaml:"minProperties"`
+	MaxItems         *int `yaml:"maxItems"`
+	MinItems         *int `yaml:"minItems"`
+	UniqueItems      bool `yaml:"uniqueItems"`
+	MaxProperties    *int `yaml:"maxProperties"`
+	MinProperties    *int `yaml:"minProperties"`
 	Required         []string
-	Enum             []string
+	Enum             []interface{}
 
 	Type                 string
 	AllOf                []*Schema `yaml:"allOf"`
@@ -37,6 +37,10 @@ type Schema struct {
 	Format               string
 	Default              interface{}
 
+	// AdditionalPropertiesAllowed is used instead of
+	// AdditionalProperties when the source has a boolean.
+	AdditionalPropertiesAllowed *bool `yaml:"-" openapi:"additionalProperties"`
+
 	Nullable      bool
 	Discriminator *Discriminator
 	ReadOnly      bool `yaml:"readOnly"`
@@ -46,6 +50,19 @@ type Schema struct {
 	Example       interface{}
 	Deprecated    bool
 
+	// JSON Schema 2020-12 keywords for OAS 3.1. Types and the numeric
+	// exclusive bounds are used instead of Type and the boolean ones
+	// when the source has a type array or numbers.
+	Types                 []string `yaml:"-" openapi:"type"`
+	ExclusiveMaximumValue *float64 `yaml:"-" openapi:"exclusiveMaximum"`
+	ExclusiveMinimumValue *float64 `yaml:"-" openapi:"exclusiveMinimum"`
+	Const                 interface{}
+	Defs                  map[string]*Schema `yaml:"$defs"`
+	PrefixItems           []*Schema          `yaml:"prefixItems"`
+	// Bool is set if the schema is a boolean schema. A true schema
+	// allows any value, and a false schema allows no value.
+	Bool *bool `yaml:"-"`
+
 	Ref string `yaml:"$ref"`
 
 	Extension map[string]interface{} `yaml:",inline"`
@@ -53,41 +70,144 @@ type Schema struct {
 
 // Validate the values of Schema object.
 func (schema Schema) Validate() error {
-	validaters := []validater{}
-	for _, s := range schema.AllOf {
-		validaters = append(validaters, s)
+	return validateFirst(schema)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (schema Schema) GetExtension(name string, v interface{}) error {
+	return getExtension(schema.Extension, name, v)
+}
+
+// TypeNames returns the types of the schema, which is given as type
+// array in OAS 3.1 or a type in OAS 3.0.
+func (schema Schema) TypeNames() []string {
+	if len(schema.Types) > 0 {
+		return schema.Types
+	}
+	if schema.Type != "" {
+		return []string{schema.Type}
+	}
+	return nil
+}
+
+func (schema Schema) validate(v *validator, ptr string) {
+	if !v.is31() {
+		// JSON Schema 2020-12 allows unknown keywords
+		validateExtension(v, ptr, schema.Extension)
 	}
-	for _, s := range schema.OneOf {
-		validaters = append(validaters, s)
+	schema.validateVersion(v, ptr)
+	schema.validateKeywords(v, ptr)
+	for i, s := range schema.AllOf {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "allOf", strconv.Itoa(i)))
+		}
 	}
-	for _, s := range schema.AnyOf {
-		validaters = append(validaters, s)
+	for i, s := range schema.OneOf {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "oneOf", strconv.Itoa(i)))
+		}
+	}
+	for i, s := range schema.AnyOf {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "anyOf", strconv.Itoa(i)))
+		}
 	}
 	if schema.Not != nil {
-		validaters = append(validaters, schema.Not)
+		schema.Not.validate(v, joinPointer(ptr, "not"))
 	}
 	if schema.Items != nil {
-		validaters = append(validaters, schema.Items)
+		schema.Items.validate(v, joinPointer(ptr, "items"))
+	}
+	if schema.AdditionalProperties != nil {
+		schema.AdditionalProperties.validate(v, joinPointer(ptr, "additionalProperties"))
 	}
 	if schema.Discriminator != nil {
-		validaters = append(validaters, schema.Discriminator)
+		schema.Discriminator.validate(v, joinPointer(ptr, "discriminator"))
 	}
 	if schema.XML != nil {
-		validaters = append(validaters, schema.XML)
+		schema.XML.validate(v, joinPointer(ptr, "xml"))
 	}
 	if schema.ExternalDocs != nil {
-		validaters = append(validaters, schema.ExternalDocs)
+		schema.ExternalDocs.validate(v, joinPointer(ptr, "externalDocs"))
 	}
-	for _, property := range schema.Properties {
-		validaters = append(validaters, property)
+	for name, property := range schema.Properties {
+		if property != nil {
+			property.validate(v, joinPointer(ptr, "properties", name))
+		}
 	}
-	if e, ok := schema.Example.(validater); ok {
-		validaters = append(validaters, e)
+	for i, s := range schema.PrefixItems {
+		if s != nil {
+			s.validate(v, joinPointer(ptr, "prefixItems", strconv.Itoa(i)))
+		}
 	}
-	for k := range schema.Extension {
-		if !strings.HasPrefix(k, "x-") {
-			return fmt.Errorf("unknown field: %s", k)
+	for name, def := range schema.Defs {
+		if def != nil {
+			def.validate(v, joinPointer(ptr, "$defs", name))
+		}
+	}
+	if e, ok := schema.Example.(pointerValidater); ok {
+		e.validate(v, joinPointer(ptr, "example"))
+	}
+	v.validateExample(&schema, schema.Example, joinPointer(ptr, "example"))
+	v.validateExample(&schema, schema.Default, joinPointer(ptr, "default"))
+}
+
+// validateKeywords reports the numeric keywords whose values are out
+// of their ranges.
+func (schema Schema) validateKeywords(v *validator, ptr string) {
+	if m := schema.MultipleOf; m != nil && *m <= 0 {
+		v.report(joinPointer(ptr, "multipleOf"), ErrFormatInvalid{Target: "schema.multipleOf", Format: "positive number"})
+	}
+	for _, keyword := range []struct {
+		name  string
+		value *int
+	}{
+		{"maxLength", schema.MaxLength},
+		{"minLength", schema.MinLength},
+		{"maxItems", schema.MaxItems},
+		{"minItems", schema.MinItems},
+		{"maxProperties", schema.MaxProperties},
+		{"minProperties", schema.MinProperties},
+	} {
+		if keyword.value != nil && *keyword.value < 0 {
+			v.report(joinPointer(ptr, keyword.name), ErrFormatInvalid{Target: "schema." + keyword.name, Format: "non-negative integer"})
+		}
+	}
+}
+
+// validateVersion reports the keywords which are not supported in the
+// OAS version of the document.
+func (schema Schema) validateVersion(v *validator, ptr string) {
+	switch {
+	case v.is30():
+		if len(schema.Types) > 0 {
+			v.report(joinPointer(ptr, "type"), ErrFormatInvalid{Target: "schema.type", Format: "string"})
+		}
+		if schema.ExclusiveMaximumValue != nil {
+			v.report(joinPointer(ptr, "exclusiveMaximum"), ErrFormatInvalid{Target: "schema.exclusiveMaximum", Format: "boolean"})
+		}
+		if schema.ExclusiveMinimumValue != nil {
+			v.report(joinPointer(ptr, "exclusiveMinimum"), ErrFormatInvalid{Target: "schema.exclusiveMinimum", Format: "boolean"})
+		}
+		if schema.Const != nil {
+			v.reportUnsupported(ptr, "const")
+		}
+		if schema.Defs != nil {
+			v.reportUnsupported(ptr, "$defs")
+		}
+		if schema.PrefixItems != nil {
+			v.reportUnsupported(ptr, "prefixItems")
+		}
+	case v.is31():
+		if schema.ExclusiveMaximum {
+			v.report(joinPointer(ptr, "exclusiveMaximum"), ErrFormatInvalid{Target: "schema.exclusiveMaximum", Format: "number"})
+		}
+		if schema.ExclusiveMinimum {
+			v.report(joinPointer(ptr, "exclusiveMinimum"), ErrFormatInvalid{Target: "schema.exclusiveMinimum", Format: "number"})
+		}
+		if schema.Nullable {
+			v.reportUnsupported(ptr, "nullable")
 		}
 	}
-	return validateAll(validaters)
 }
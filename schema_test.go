@@ -3,19 +3,116 @@ package openapi_test
 import (
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	openapi "github.com/nasa9084/go-openapi"
 	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 func TestSchema_Validate(t *testing.T) {
+	zero, negative := 0, -1
+	zeroFloat := 0.0
 	candidates := []candidate{
 		{"empty", openapi.Schema{}, nil},
+		{"zero maxLength", openapi.Schema{MaxLength: &zero}, nil},
+		{"negative maxLength", openapi.Schema{MaxLength: &negative}, openapi.ErrFormatInvalid{Target: "schema.maxLength", Format: "non-negative integer"}},
+		{"negative minItems", openapi.Schema{MinItems: &negative}, openapi.ErrFormatInvalid{Target: "schema.minItems", Format: "non-negative integer"}},
+		{"zero multipleOf", openapi.Schema{MultipleOf: &zeroFloat}, openapi.ErrFormatInvalid{Target: "schema.multipleOf", Format: "positive number"}},
+		{"invalid additionalProperties", openapi.Schema{AdditionalProperties: &openapi.Schema{MaxProperties: &negative}}, openapi.ErrFormatInvalid{Target: "schema.maxProperties", Format: "non-negative integer"}},
 	}
 	testValidater(t, candidates)
 }
 
+func TestLoad_SchemaKeywords(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: keywords
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Price:
+      type: object
+      additionalProperties: false
+      properties:
+        amount:
+          type: number
+          minimum: 0.5
+          maximum: 99.99
+          multipleOf: 0.01
+        code:
+          type: string
+          maxLength: 0
+        tags:
+          type: array
+          uniqueItems: true
+          items:
+            enum: [1, 2.5, "three", true, null]
+            nullable: true
+    Free:
+      type: object
+      additionalProperties: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	price := doc.Components.Schemas["Price"]
+	amount := price.Properties["amount"]
+	if amount.Minimum == nil || *amount.Minimum != 0.5 || amount.Maximum == nil || *amount.Maximum != 99.99 || amount.MultipleOf == nil || *amount.MultipleOf != 0.01 {
+		t.Errorf("unexpected amount: %+v", amount)
+	}
+	if code := price.Properties["code"]; code.MaxLength == nil || *code.MaxLength != 0 || code.MinLength != nil {
+		t.Errorf("unexpected code: %+v", code)
+	}
+	tags := price.Properties["tags"]
+	if !tags.UniqueItems {
+		t.Error("uniqueItems should be true")
+	}
+	if want := []interface{}{1, 2.5, "three", true, nil}; !reflect.DeepEqual(tags.Items.Enum, want) {
+		t.Errorf("%#v != %#v", tags.Items.Enum, want)
+	}
+	if price.AdditionalProperties != nil || price.AdditionalPropertiesAllowed == nil || *price.AdditionalPropertiesAllowed {
+		t.Errorf("additionalProperties should be false: %+v", price)
+	}
+	if free := doc.Components.Schemas["Free"]; free.AdditionalPropertiesAllowed == nil || !*free.AdditionalPropertiesAllowed {
+		t.Errorf("additionalProperties should be true: %+v", free)
+	}
+
+	b, err := yamlv3.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"additionalProperties: false", "additionalProperties: true", "maxLength: 0", "multipleOf: 0.01", "uniqueItems: true", "- 2.5"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%q is not in the marshaled document:\n%s", want, b)
+		}
+	}
+
+	candidates := []struct {
+		label    string
+		value    interface{}
+		expected []string
+	}{
+		{"valid", map[string]interface{}{"amount": 12.34, "code": "", "tags": []interface{}{1, "three", nil}}, nil},
+		{"fraction", map[string]interface{}{"amount": 0.505}, []string{"/amount"}},
+		{"minimum", map[string]interface{}{"amount": 0.25}, []string{"/amount"}},
+		{"maxLength zero", map[string]interface{}{"code": "a"}, []string{"/code"}},
+		{"enum", map[string]interface{}{"tags": []interface{}{2.5, false}}, []string{"/tags/1"}},
+		{"uniqueItems", map[string]interface{}{"tags": []interface{}{1, 2.5, 1.0}}, []string{"/tags/2"}},
+		{"additionalProperties", map[string]interface{}{"other": 1}, []string{"/other"}},
+	}
+	for _, c := range candidates {
+		t.Run(c.label, func(t *testing.T) {
+			err := price.ValidateValue(doc, c.value)
+			if got := validationPointers(t, err); !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("%v != %v: %v", got, c.expected, err)
+			}
+		})
+	}
+}
+
 func TestSchameUnmarshal(t *testing.T) {
 	tests := []struct {
 		data string
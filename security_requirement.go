@@ -3,6 +3,7 @@ package openapi
 import (
 	"encoding/json"
 	"sort"
+	"strconv"
 )
 
 // codebeat:disable[TOO_MANY_IVARS]
@@ -28,6 +29,25 @@ func (secReq *SecurityRequirement) UnmarshalYAML(unmarshal func(interface{}) err
 	return unmarshal(&secReq.mp)
 }
 
+// MarshalJSON implements json.Marshaler.
+func (secReq SecurityRequirement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(secReq.requirements())
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (secReq SecurityRequirement) MarshalYAML() (interface{}, error) {
+	return secReq.requirements(), nil
+}
+
+// requirements returns the requirements as a map, which is not nil
+// even if there is no requirement, not to be marshaled as null.
+func (secReq SecurityRequirement) requirements() map[string][]string {
+	if secReq.mp == nil {
+		return map[string][]string{}
+	}
+	return secReq.mp
+}
+
 // Get returns required security schemes. If there is not given name,
 // this function returns nil.
 func (secReq SecurityRequirement) Get(name string) []string {
@@ -51,42 +71,87 @@ func (secReq SecurityRequirement) Names() []string {
 
 // Validate the values of SecurityRequirement object.
 func (secReq SecurityRequirement) Validate() error {
+	return validateFirst(secReq)
+}
+
+func (secReq SecurityRequirement) validate(v *validator, ptr string) {
 	if len(secReq.mp) == 0 {
-		return nil
+		return
 	}
 	if secReq.document == nil {
-		return ErrMissingRootDocument
+		v.report(ptr, ErrMissingRootDocument)
+		return
 	}
 	components := secReq.document.Components
 	if components == nil {
-		return ErrRequired{Target: "components object in parent document"}
+		v.report(ptr, ErrRequired{Target: "components object in parent document"})
+		return
 	}
-	return secReq.validateScopes()
+	secReq.validateScopes(v, ptr)
 }
 
-func (secReq SecurityRequirement) validateScopes() error {
+func (secReq SecurityRequirement) validateScopes(v *validator, ptr string) {
 	for name, scopes := range secReq.mp {
 		secScheme, ok := secReq.document.Components.SecuritySchemes[name]
-		if !ok {
-			return ErrNotDeclared{Name: name}
+		if !ok || secScheme == nil {
+			v.report(joinPointer(ptr, name), ErrNotDeclared{Name: name})
+			continue
 		}
-		if secScheme.Type != OAuth2Type {
-			if len(scopes) != 0 {
-				return ErrMustEmpty{Type: string(secScheme.Type)}
+		if secScheme.Ref != "" {
+			resolved, err := resolveChain(secReq.document, secScheme.Ref, secScheme)
+			if err != nil {
+				v.report(joinPointer(ptr, name), ErrUnresolvedRef{Ref: secScheme.Ref, Err: err})
+				continue
 			}
-			continue
+			secScheme = resolved.(*SecurityScheme)
 		}
-		for _, scope := range scopes {
-			_, implicit := secScheme.Flows.Implicit.Scopes[scope]
-			_, password := secScheme.Flows.Password.Scopes[scope]
-			_, cc := secScheme.Flows.ClientCredentials.Scopes[scope]
-			_, ac := secScheme.Flows.AuthorizationCode.Scopes[scope]
-			if !implicit && !password && !cc && !ac {
-				return ErrNotDeclared{Name: scope}
+		switch secScheme.Type {
+		case OAuth2Type:
+			secReq.validateFlowScopes(v, joinPointer(ptr, name), secScheme.Flows, scopes)
+		case OpenIDConnectType:
+			// the scopes are discovered from openIdConnectUrl
+		default:
+			// OAS 3.1 allows the role names for the other types
+			if len(scopes) != 0 && !v.is31() {
+				v.report(joinPointer(ptr, name), ErrMustEmpty{Type: string(secScheme.Type)})
 			}
 		}
 	}
-	return nil
+}
+
+// validateFlowScopes reports the scopes which are declared in none of
+// the flows defined in the OAuth2 security scheme, as the scope is
+// requested by any of the flows. The flows which are not defined are
+// not checked.
+func (secReq SecurityRequirement) validateFlowScopes(v *validator, ptr string, flows *OAuthFlows, scopes []string) {
+	if flows == nil {
+		return // reported by the security scheme
+	}
+	for i, scope := range scopes {
+		var checked []string
+		declared := false
+		for _, flow := range []struct {
+			name string
+			flow *OAuthFlow
+		}{
+			{"implicit", flows.Implicit},
+			{"password", flows.Password},
+			{"clientCredentials", flows.ClientCredentials},
+			{"authorizationCode", flows.AuthorizationCode},
+		} {
+			if flow.flow == nil {
+				continue
+			}
+			checked = append(checked, flow.name)
+			if _, ok := flow.flow.Scopes[scope]; ok {
+				declared = true
+				break
+			}
+		}
+		if !declared && len(checked) > 0 {
+			v.report(joinPointer(ptr, strconv.Itoa(i)), ErrScopeNotDeclared{Scope: scope, Flows: checked})
+		}
+	}
 }
 
 func (secReq *SecurityRequirement) setDocument(doc *Document) {
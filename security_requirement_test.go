@@ -3,6 +3,7 @@ package openapi_test
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	openapi "github.com/nasa9084/go-openapi"
@@ -93,3 +94,127 @@ func TestSecurityRequirement_UnmarshalJSON(t *testing.T) {
 		return
 	}
 }
+
+const securitySpec = `openapi: 3.0.3
+info:
+  title: security
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      security:
+        - clientOnly: [read]
+        - clientOnly: [write]
+        - mixed: [read, admin]
+        - referenced: [read, write]
+        - oidc: [openid, profile]
+        - apiKey: [read]
+        - unknown: []
+        - split: [read]
+        - split: [write]
+      responses:
+        '200':
+          description: ok
+components:
+  securitySchemes:
+    clientOnly:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            read: read pets
+    mixed:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/authorize
+          scopes:
+            read: read pets
+        password:
+          tokenUrl: https://example.com/token
+          scopes:
+            read: read pets
+            admin: administrate pets
+        authorizationCode:
+          authorizationUrl: https://example.com/authorize
+          tokenUrl: https://example.com/token
+          scopes:
+            read: read pets
+            admin: administrate pets
+    split:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            admin: administrate pets
+        authorizationCode:
+          authorizationUrl: https://example.com/authorize
+          tokenUrl: https://example.com/token
+          scopes:
+            read: read pets
+    referenced:
+      $ref: '#/components/securitySchemes/clientOnly'
+    oidc:
+      type: openIdConnect
+      openIdConnectUrl: https://example.com/.well-known/openid-configuration
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+`
+
+func TestSecurityRequirement_ValidateScopes(t *testing.T) {
+	doc, err := openapi.Load([]byte(securitySpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.ValidateAll()
+	errs, ok := err.(openapi.ErrorList)
+	if !ok {
+		t.Fatalf("error should be ErrorList, but %v", err)
+	}
+	got := map[string]error{}
+	for _, e := range errs {
+		got[e.Pointer] = e.Err
+	}
+	const ptr = "/paths/~1pets/get/security/"
+	want := map[string]error{
+		ptr + "1/clientOnly/0": openapi.ErrScopeNotDeclared{Scope: "write", Flows: []string{"clientCredentials"}},
+		ptr + "3/referenced/1": openapi.ErrScopeNotDeclared{Scope: "write", Flows: []string{"clientCredentials"}},
+		ptr + "5/apiKey":       openapi.ErrMustEmpty{Type: "apiKey"},
+		ptr + "6/unknown":      openapi.ErrNotDeclared{Name: "unknown"},
+		ptr + "8/split/0":      openapi.ErrScopeNotDeclared{Scope: "write", Flows: []string{"clientCredentials", "authorizationCode"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected errors:\n  got:\t%+v\n  want:\t%+v", got, want)
+	}
+
+	// OAS 3.1 allows the role names for the other types
+	doc, err = openapi.Load([]byte(strings.Replace(securitySpec, "3.0.3", "3.1.0", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, _ = doc.ValidateAll().(openapi.ErrorList)
+	for _, e := range errs {
+		if e.Pointer == ptr+"5/apiKey" {
+			t.Errorf("unexpected error: %v", e)
+		}
+	}
+}
+
+func TestSecurityRequirement_ValidateScopes_Swagger(t *testing.T) {
+	// petstore_auth has only the implicit flow
+	doc, err := openapi.LoadSwaggerFile("testdata/swagger/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, _ := doc.ValidateAll().(openapi.ErrorList)
+	for _, e := range errs {
+		if strings.Contains(e.Pointer, "/security/") {
+			t.Errorf("unexpected error: %v", e)
+		}
+	}
+}
@@ -14,6 +14,8 @@ type SecurityScheme struct {
 	OpenIDConnectURL string `yaml:"openIdConnectUrl"`
 
 	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // SecuritySchemeType represents a securityScheme.type value.
@@ -31,49 +33,68 @@ const (
 var SecuritySchemeTypeList = []string{string(APIKeyType), string(HTTPType), string(OAuth2Type), string(OpenIDConnectType)}
 
 // Validate the values of SecurityScheme object.
+// The security scheme with $ref is not validated, like Response, as
+// the referenced one is validated in the components of the Document.
 func (secScheme SecurityScheme) Validate() error {
+	return validateFirst(secScheme)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (secScheme SecurityScheme) GetExtension(name string, v interface{}) error {
+	return getExtension(secScheme.Extension, name, v)
+}
+
+func (secScheme SecurityScheme) validate(v *validator, ptr string) {
+	if secScheme.Ref != "" {
+		return // validated in doc.Components
+	}
+	validateExtension(v, ptr, secScheme.Extension)
 	switch secScheme.Type {
 	case "":
-		return ErrRequired{Target: "securityScheme.type"}
+		v.report(ptr, ErrRequired{Target: "securityScheme.type"})
 	case APIKeyType:
-		return secScheme.validateFieldForAPIKey()
+		secScheme.validateFieldForAPIKey(v, ptr)
 	case HTTPType:
-		return secScheme.validateFieldForHTTP()
+		secScheme.validateFieldForHTTP(v, ptr)
 	case OAuth2Type:
-		return secScheme.validateFieldForOAuth2()
+		secScheme.validateFieldForOAuth2(v, ptr)
 	case OpenIDConnectType:
-		return secScheme.validateFieldForOpenIDConnect()
+		secScheme.validateFieldForOpenIDConnect(v, ptr)
+	default:
+		v.report(joinPointer(ptr, "type"), ErrMustOneOf{Object: "securityScheme.type", ValidValues: SecuritySchemeTypeList})
 	}
-	return ErrMustOneOf{Object: "securityScheme.type", ValidValues: SecuritySchemeTypeList}
 }
 
-func (secScheme SecurityScheme) validateFieldForAPIKey() error {
+func (secScheme SecurityScheme) validateFieldForAPIKey(v *validator, ptr string) {
 	if secScheme.Name == "" {
-		return ErrRequired{"securityScheme.name"}
+		v.report(ptr, ErrRequired{"securityScheme.name"})
 	}
 	if secScheme.In == "" {
-		return ErrRequired{"securityScheme.in"}
+		v.report(ptr, ErrRequired{"securityScheme.in"})
+		return
 	}
 	if secScheme.In != InQuery && secScheme.In != InHeader && secScheme.In != InCookie {
-		return ErrMustOneOf{Object: "securityScheme.in", ValidValues: SecuritySchemeInList}
+		v.report(joinPointer(ptr, "in"), ErrMustOneOf{Object: "securityScheme.in", ValidValues: SecuritySchemeInList})
 	}
-	return nil
 }
 
-func (secScheme SecurityScheme) validateFieldForHTTP() error {
+func (secScheme SecurityScheme) validateFieldForHTTP(v *validator, ptr string) {
 	if secScheme.Scheme == "" {
-		return ErrRequired{Target: "securityScheme.scheme"}
+		v.report(ptr, ErrRequired{Target: "securityScheme.scheme"})
 	}
-	return nil
 }
 
-func (secScheme SecurityScheme) validateFieldForOAuth2() error {
+func (secScheme SecurityScheme) validateFieldForOAuth2(v *validator, ptr string) {
 	if secScheme.Flows == nil {
-		return ErrRequired{Target: "securityScheme.flows"}
+		v.report(ptr, ErrRequired{Target: "securityScheme.flows"})
+		return
 	}
-	return secScheme.Flows.Validate()
+	secScheme.Flows.validate(v, joinPointer(ptr, "flows"))
 }
 
-func (secScheme SecurityScheme) validateFieldForOpenIDConnect() error {
-	return mustURL("securityScheme.openIdConnectUrl", secScheme.OpenIDConnectURL)
+func (secScheme SecurityScheme) validateFieldForOpenIDConnect(v *validator, ptr string) {
+	if err := mustURL("securityScheme.openIdConnectUrl", secScheme.OpenIDConnectURL); err != nil {
+		v.report(joinPointer(ptr, "openIdConnectUrl"), err)
+	}
 }
@@ -21,6 +21,7 @@ func TestSecurityScheme_Validate(t *testing.T) {
 		{"openIdConnect/noOIDCURL", openapi.SecurityScheme{Type: "openIdConnect"}, openapi.ErrRequired{Target: "securityScheme.openIdConnectUrl"}},
 		{"openIdConnect/valid", openapi.SecurityScheme{Type: "openIdConnect", OpenIDConnectURL: "http://example.com"}, nil},
 		{"invalidType", openapi.SecurityScheme{Type: "foo"}, openapi.ErrMustOneOf{Object: "securityScheme.type", ValidValues: openapi.SecuritySchemeTypeList}},
+		{"reference", openapi.SecurityScheme{Ref: "#/components/securitySchemes/foo"}, nil},
 	}
 	testValidater(t, candidates)
 }
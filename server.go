@@ -14,25 +14,50 @@ type Server struct {
 	URL         string
 	Description string
 	Variables   map[string]*ServerVariable
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Server object.
 func (server Server) Validate() error {
+	return validateFirst(server)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (server Server) GetExtension(name string, v interface{}) error {
+	return getExtension(server.Extension, name, v)
+}
+
+func (server Server) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, server.Extension)
 	if err := server.validateRequiredFields(); err != nil {
-		return err
+		v.report(ptr, err)
+		return
 	}
 	// replace template variable with placeholder to validate the replaced string
 	// is valid URL or not
 	serverURL := tmplVarRegexp.ReplaceAllLiteralString(server.URL, "ph")
 	// use url.Parse because relative URL is allowed
 	if _, err := url.Parse(serverURL); err != nil {
-		return ErrFormatInvalid{Target: "server.url", Format: "URL"}
+		v.report(joinPointer(ptr, "url"), ErrFormatInvalid{Target: "server.url", Format: "URL"})
 	}
-	validaters := []validater{}
-	for _, sv := range server.Variables {
-		validaters = append(validaters, sv)
+	for name, sv := range server.Variables {
+		if sv != nil {
+			sv.validate(v, joinPointer(ptr, "variables", name))
+		}
 	}
-	return validateAll(validaters)
+}
+
+// defaultURL returns the URL of the server whose template variables
+// are replaced with their default values.
+func (server Server) defaultURL() string {
+	return tmplVarRegexp.ReplaceAllStringFunc(server.URL, func(tmpl string) string {
+		if sv, ok := server.Variables[tmpl[1:len(tmpl)-1]]; ok && sv != nil {
+			return sv.Default
+		}
+		return tmpl
+	})
 }
 
 func (server Server) validateRequiredFields() error {
@@ -7,12 +7,24 @@ type ServerVariable struct {
 	Enum        []string
 	Default     string
 	Description string
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Server Variable object.
 func (sv ServerVariable) Validate() error {
+	return validateFirst(sv)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (sv ServerVariable) GetExtension(name string, v interface{}) error {
+	return getExtension(sv.Extension, name, v)
+}
+
+func (sv ServerVariable) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, sv.Extension)
 	if sv.Default == "" {
-		return ErrRequired{Target: "serverVariable.default"}
+		v.report(ptr, ErrRequired{Target: "serverVariable.default"})
 	}
-	return nil
 }
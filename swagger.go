@@ -0,0 +1,854 @@
+package openapi
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// codebeat:disable[TOO_MANY_IVARS]
+
+// swagger is a Swagger 2.0 document, which is converted into OpenAPI
+// 3.0 document. The schema objects are decoded as the ones of OpenAPI
+// 3.0, as they are almost same.
+type swagger struct {
+	Swagger             string
+	Info                *Info
+	Host                string
+	BasePath            string `yaml:"basePath"`
+	Schemes             []string
+	Consumes            []string
+	Produces            []string
+	Paths               swaggerPaths
+	Definitions         map[string]*Schema
+	Parameters          map[string]*swaggerParameter
+	Responses           swaggerResponses
+	SecurityDefinitions map[string]*swaggerSecurityScheme `yaml:"securityDefinitions"`
+	Security            []*SecurityRequirement
+	Tags                []*Tag
+	ExternalDocs        *ExternalDocumentation `yaml:"externalDocs"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+// swaggerPaths and swaggerResponses are the map objects of Swagger 2.0,
+// which may be extended with specification extensions.
+type (
+	swaggerPaths     map[string]*swaggerPathItem
+	swaggerResponses map[string]*swaggerResponse
+)
+
+type swaggerPathItem struct {
+	Ref        string `yaml:"$ref"`
+	Get        *swaggerOperation
+	Put        *swaggerOperation
+	Post       *swaggerOperation
+	Delete     *swaggerOperation
+	Options    *swaggerOperation
+	Head       *swaggerOperation
+	Patch      *swaggerOperation
+	Parameters []*swaggerParameter
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+type swaggerOperation struct {
+	Tags         []string
+	Summary      string
+	Description  string
+	ExternalDocs *ExternalDocumentation `yaml:"externalDocs"`
+	OperationID  string                 `yaml:"operationId"`
+	Consumes     []string
+	Produces     []string
+	Parameters   []*swaggerParameter
+	Responses    swaggerResponses
+	Schemes      []string
+	Deprecated   bool
+	Security     []*SecurityRequirement
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+type swaggerParameter struct {
+	Name            string
+	In              string
+	Description     string
+	Required        bool
+	AllowEmptyValue bool `yaml:"allowEmptyValue"`
+	Schema          *Schema
+	// Primitive holds the type of the parameter which is not in body.
+	Primitive swaggerItems `yaml:",inline"`
+
+	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+// swaggerItems is the type of the parameters which are not in body,
+// the headers and the items of them, which is a subset of schema
+// object.
+type swaggerItems struct {
+	Type             string
+	Format           string
+	Items            *swaggerItems
+	CollectionFormat string `yaml:"collectionFormat"`
+	Default          interface{}
+	Maximum          *float64
+	ExclusiveMaximum bool `yaml:"exclusiveMaximum"`
+	Minimum          *float64
+	ExclusiveMinimum bool `yaml:"exclusiveMinimum"`
+	MaxLength        *int `yaml:"maxLength"`
+	MinLength        *int `yaml:"minLength"`
+	Pattern          string
+	MaxItems         *int `yaml:"maxItems"`
+	MinItems         *int `yaml:"minItems"`
+	UniqueItems      bool `yaml:"uniqueItems"`
+	Enum             []interface{}
+	MultipleOf       *float64 `yaml:"multipleOf"`
+}
+
+type swaggerHeader struct {
+	Description string
+	Primitive   swaggerItems `yaml:",inline"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+type swaggerResponse struct {
+	Description string
+	Schema      *Schema
+	Headers     map[string]*swaggerHeader
+	Examples    map[string]interface{}
+
+	Ref string `yaml:"$ref"`
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+type swaggerSecurityScheme struct {
+	Type             string
+	Description      string
+	Name             string
+	In               InType
+	Flow             string
+	AuthorizationURL string `yaml:"authorizationUrl"`
+	TokenURL         string `yaml:"tokenUrl"`
+	Scopes           map[string]string
+
+	Extension map[string]interface{} `yaml:",inline"`
+}
+
+// LoadSwagger loads Swagger 2.0 spec and converts it into OpenAPI
+// Specification v3.0 document. The spec is parsed as JSON if it looks
+// like JSON, otherwise as YAML.
+// If the spec is not Swagger 2.0, ErrUnsupportedVersion is returned.
+func LoadSwagger(b []byte) (*Document, error) {
+	node, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	convertSwaggerDiscriminator(node)
+	var src swagger
+	d := newDecoder("")
+	if err := d.decode(node, reflect.ValueOf(&src).Elem(), ""); err != nil {
+		return nil, err
+	}
+	if src.Swagger != "2.0" {
+		return nil, ErrUnsupportedVersion
+	}
+	c := &swaggerConverter{src: &src, maps: d.maps}
+	return c.convert(), nil
+}
+
+// LoadSwaggerFile loads Swagger 2.0 spec file and converts it into
+// OpenAPI Specification v3.0 document. See LoadSwagger.
+func LoadSwaggerFile(filename string) (*Document, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSwagger(b)
+}
+
+// convertSwaggerDiscriminator rewrites the discriminators of Swagger
+// 2.0, which are property names, into discriminator objects.
+func convertSwaggerDiscriminator(node *yaml.Node) {
+	eachSwaggerSchema(node, func(schema *yaml.Node) {
+		for i := 0; i+1 < len(schema.Content); i += 2 {
+			key, value := schema.Content[i], schema.Content[i+1]
+			if key.Value == "discriminator" && value.Kind == yaml.ScalarNode {
+				schema.Content[i+1] = &yaml.Node{
+					Kind:    yaml.MappingNode,
+					Tag:     "!!map",
+					Line:    value.Line,
+					Column:  value.Column,
+					Content: []*yaml.Node{stringNode("propertyName"), value},
+				}
+			}
+		}
+	})
+}
+
+// swaggerMethods are the keys of the operations in a path item of
+// Swagger 2.0.
+var swaggerMethods = map[string]struct{}{
+	"get": {}, "put": {}, "post": {}, "delete": {}, "options": {}, "head": {}, "patch": {},
+}
+
+// eachSwaggerSchema calls fn with each schema object in the Swagger 2.0
+// document node, including the nested ones. The values which are not
+// schemas, like examples, defaults or extensions, are not visited.
+func eachSwaggerSchema(node *yaml.Node, fn func(schema *yaml.Node)) {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return
+		}
+		node = node.Content[0]
+	}
+	eachMappingValue(mappingValue(node, "definitions"), func(_ string, schema *yaml.Node) {
+		eachSubschema(schema, fn)
+	})
+	eachSwaggerParameterSchema(mappingValue(node, "parameters"), fn)
+	eachSwaggerResponseSchema(mappingValue(node, "responses"), fn)
+	eachMappingValue(mappingValue(node, "paths"), func(path string, pathItem *yaml.Node) {
+		if strings.HasPrefix(path, extensionPrefix) {
+			return
+		}
+		eachSwaggerParameterSchema(mappingValue(pathItem, "parameters"), fn)
+		eachMappingValue(pathItem, func(method string, op *yaml.Node) {
+			if _, ok := swaggerMethods[method]; !ok {
+				return
+			}
+			eachSwaggerParameterSchema(mappingValue(op, "parameters"), fn)
+			eachSwaggerResponseSchema(mappingValue(op, "responses"), fn)
+		})
+	})
+}
+
+// eachSwaggerParameterSchema calls fn with the schemas of the body
+// parameters, which are a sequence or a map of parameters.
+func eachSwaggerParameterSchema(node *yaml.Node, fn func(schema *yaml.Node)) {
+	each := func(_ string, parameter *yaml.Node) {
+		eachSubschema(mappingValue(parameter, "schema"), fn)
+	}
+	eachMappingValue(node, each)
+	eachSequenceValue(node, each)
+}
+
+// eachSwaggerResponseSchema calls fn with the schemas of the responses.
+func eachSwaggerResponseSchema(node *yaml.Node, fn func(schema *yaml.Node)) {
+	eachMappingValue(node, func(status string, response *yaml.Node) {
+		if strings.HasPrefix(status, extensionPrefix) {
+			return
+		}
+		eachSubschema(mappingValue(response, "schema"), fn)
+	})
+}
+
+// eachSubschema calls fn with the schema and its subschemas.
+func eachSubschema(schema *yaml.Node, fn func(schema *yaml.Node)) {
+	schema = dealias(schema)
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+	fn(schema)
+	recurse := func(_ string, subschema *yaml.Node) {
+		eachSubschema(subschema, fn)
+	}
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		switch value := schema.Content[i+1]; schema.Content[i].Value {
+		case "properties":
+			eachMappingValue(value, recurse)
+		case "items", "additionalProperties":
+			eachSequenceValue(value, recurse)
+			eachSubschema(value, fn)
+		case "allOf":
+			eachSequenceValue(value, recurse)
+		}
+	}
+}
+
+// mappingValue returns the value of the key in the mapping node, or
+// nil if it is not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = dealias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// eachMappingValue calls fn with each key and value of the mapping
+// node.
+func eachMappingValue(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	node = dealias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i].Value, node.Content[i+1])
+	}
+}
+
+// eachSequenceValue calls fn with each item of the sequence node.
+func eachSequenceValue(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	node = dealias(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, n := range node.Content {
+		fn(strconv.Itoa(i), n)
+	}
+}
+
+func dealias(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.AliasNode {
+		return node.Alias
+	}
+	return node
+}
+
+// swaggerConverter converts Swagger 2.0 document into OpenAPI 3.0.
+type swaggerConverter struct {
+	src *swagger
+	// maps holds the information of the maps in src.
+	maps mapInfos
+	doc  *Document
+}
+
+func (c *swaggerConverter) convert() *Document {
+	src := c.src
+	c.doc = &Document{
+		Version:      "3.0.3",
+		Info:         src.Info,
+		Servers:      c.servers(src.Schemes),
+		Paths:        Paths{},
+		Security:     src.Security,
+		Tags:         src.Tags,
+		ExternalDocs: src.ExternalDocs,
+		Extension:    swaggerExtension(src.Extension),
+		maps:         c.maps,
+	}
+	for path, pathItem := range src.Paths {
+		c.doc.Paths[path] = c.pathItem(pathItem)
+	}
+	c.copyKeys(c.doc.Paths, src.Paths)
+	c.copyExtension(c.doc.Paths, src.Paths)
+	c.doc.Components = c.components()
+
+	w := &refWalker{
+		onRef: func(ptr, ref string, obj interface{}) interface{} {
+			setRef(obj, convertSwaggerRef(ref))
+			return nil
+		},
+	}
+	w.document(c.doc)
+	c.doc.setSecurityDocument()
+	return c.doc
+}
+
+// servers returns the server objects for the schemes, with host and
+// basePath of the document.
+func (c *swaggerConverter) servers(schemes []string) []*Server {
+	if c.src.Host == "" {
+		url := c.src.BasePath
+		if url == "" {
+			url = "/"
+		}
+		return []*Server{{URL: url}}
+	}
+	if len(schemes) == 0 {
+		// the scheme used to access the spec
+		return []*Server{{URL: "//" + c.src.Host + c.src.BasePath}}
+	}
+	servers := make([]*Server, len(schemes))
+	for i, scheme := range schemes {
+		servers[i] = &Server{URL: scheme + "://" + c.src.Host + c.src.BasePath}
+	}
+	return servers
+}
+
+// consumes returns the media types of the request bodies. If the
+// operation does not specify them, the ones of the document are used.
+func (c *swaggerConverter) consumes(consumes []string) []string {
+	if len(consumes) > 0 {
+		return consumes
+	}
+	if len(c.src.Consumes) > 0 {
+		return c.src.Consumes
+	}
+	return []string{"application/json"}
+}
+
+// produces returns the media types of the responses. If the operation
+// does not specify them, the ones of the document are used.
+func (c *swaggerConverter) produces(produces []string) []string {
+	if len(produces) > 0 {
+		return produces
+	}
+	if len(c.src.Produces) > 0 {
+		return c.src.Produces
+	}
+	return []string{"application/json"}
+}
+
+func (c *swaggerConverter) components() *Components {
+	components := &Components{Schemas: c.src.Definitions}
+	for _, name := range c.keys(c.src.Parameters) {
+		parameter := c.src.Parameters[name]
+		switch parameter.In {
+		case "body":
+			if components.RequestBodies == nil {
+				components.RequestBodies = map[string]*RequestBody{}
+			}
+			components.RequestBodies[name] = c.requestBody(parameter, c.consumes(nil))
+		case "formData":
+			// inlined into the operations, as form data parameters
+			// are a part of the request body schema.
+		default:
+			if components.Parameters == nil {
+				components.Parameters = map[string]*Parameter{}
+			}
+			components.Parameters[name] = c.parameter(parameter)
+		}
+	}
+	if len(c.src.Responses) > 0 {
+		components.Responses = map[string]*Response{}
+		for name, response := range c.src.Responses {
+			components.Responses[name] = c.response(response, c.produces(nil))
+		}
+		c.copyKeys(components.Responses, c.src.Responses)
+	}
+	if len(c.src.SecurityDefinitions) > 0 {
+		components.SecuritySchemes = map[string]*SecurityScheme{}
+		for name, secScheme := range c.src.SecurityDefinitions {
+			components.SecuritySchemes[name] = c.securityScheme(secScheme)
+		}
+		c.copyKeys(components.SecuritySchemes, c.src.SecurityDefinitions)
+	}
+	if reflect.ValueOf(*components).IsZero() {
+		return nil
+	}
+	c.copyKeys(components.RequestBodies, c.src.Parameters)
+	c.copyKeys(components.Parameters, c.src.Parameters)
+	return components
+}
+
+func (c *swaggerConverter) pathItem(src *swaggerPathItem) *PathItem {
+	pathItem := &PathItem{
+		Ref:       src.Ref,
+		Extension: swaggerExtension(src.Extension),
+	}
+	// the body and form data parameters are moved into the operations
+	var common []*swaggerParameter
+	for _, parameter := range src.Parameters {
+		switch c.resolveParameter(parameter).In {
+		case "body", "formData":
+			common = append(common, parameter)
+		default:
+			pathItem.Parameters = append(pathItem.Parameters, c.parameter(parameter))
+		}
+	}
+	for _, op := range []struct {
+		src *swaggerOperation
+		dst **Operation
+	}{
+		{src.Get, &pathItem.Get},
+		{src.Put, &pathItem.Put},
+		{src.Post, &pathItem.Post},
+		{src.Delete, &pathItem.Delete},
+		{src.Options, &pathItem.Options},
+		{src.Head, &pathItem.Head},
+		{src.Patch, &pathItem.Patch},
+	} {
+		if op.src != nil {
+			*op.dst = c.operation(op.src, common)
+		}
+	}
+	return pathItem
+}
+
+// operation converts the operation. common is the body and form data
+// parameters of the path item, which are not overridden by the
+// operation.
+func (c *swaggerConverter) operation(src *swaggerOperation, common []*swaggerParameter) *Operation {
+	op := &Operation{
+		Tags:         src.Tags,
+		Summary:      src.Summary,
+		Description:  src.Description,
+		ExternalDocs: src.ExternalDocs,
+		OperationID:  src.OperationID,
+		Responses:    c.responses(src.Responses, c.produces(src.Produces)),
+		Deprecated:   src.Deprecated,
+		Security:     src.Security,
+		Extension:    swaggerExtension(src.Extension),
+	}
+	if len(src.Schemes) > 0 {
+		op.Servers = c.servers(src.Schemes)
+	}
+	consumes := c.consumes(src.Consumes)
+	overridden := map[string]struct{}{}
+	for _, parameter := range src.Parameters {
+		resolved := c.resolveParameter(parameter)
+		overridden[resolved.In+":"+resolved.Name] = struct{}{}
+	}
+	parameters := src.Parameters
+	for _, parameter := range common {
+		resolved := c.resolveParameter(parameter)
+		if _, ok := overridden[resolved.In+":"+resolved.Name]; !ok {
+			parameters = append(parameters, parameter)
+		}
+	}
+	var form []*swaggerParameter
+	for _, parameter := range parameters {
+		switch c.resolveParameter(parameter).In {
+		case "body":
+			op.RequestBody = c.requestBody(parameter, consumes)
+		case "formData":
+			form = append(form, c.resolveParameter(parameter))
+		default:
+			op.Parameters = append(op.Parameters, c.parameter(parameter))
+		}
+	}
+	if len(form) > 0 {
+		op.RequestBody = c.formBody(form, consumes)
+	}
+	return op
+}
+
+// resolveParameter returns the parameter which the local reference
+// points. If the parameter is not a reference or cannot be resolved,
+// the parameter itself is returned.
+func (c *swaggerConverter) resolveParameter(parameter *swaggerParameter) *swaggerParameter {
+	if strings.HasPrefix(parameter.Ref, "#/parameters/") {
+		name := unescapePointer(strings.TrimPrefix(parameter.Ref, "#/parameters/"))
+		if resolved, ok := c.src.Parameters[name]; ok {
+			return resolved
+		}
+	}
+	return parameter
+}
+
+func (c *swaggerConverter) parameter(src *swaggerParameter) *Parameter {
+	if src.Ref != "" {
+		return &Parameter{Ref: src.Ref}
+	}
+	parameter := &Parameter{
+		Name:            src.Name,
+		In:              InType(src.In),
+		Description:     src.Description,
+		Required:        src.Required,
+		AllowEmptyValue: src.AllowEmptyValue,
+		Schema:          src.Primitive.schema(),
+		Extension:       swaggerExtension(src.Extension),
+	}
+	if src.Primitive.Type == "array" {
+		switch src.Primitive.CollectionFormat {
+		case "multi":
+			explode := true
+			parameter.Style, parameter.Explode = "form", &explode
+		case "ssv":
+			parameter.Style = "spaceDelimited"
+		case "pipes":
+			parameter.Style = "pipeDelimited"
+		default: // csv
+			if parameter.In == InQuery {
+				explode := false
+				parameter.Style, parameter.Explode = "form", &explode
+			}
+		}
+	}
+	return parameter
+}
+
+// requestBody converts the body parameter into request body object.
+func (c *swaggerConverter) requestBody(src *swaggerParameter, consumes []string) *RequestBody {
+	if strings.HasPrefix(src.Ref, "#/parameters/") {
+		return &RequestBody{Ref: "#/components/requestBodies/" + strings.TrimPrefix(src.Ref, "#/parameters/")}
+	}
+	content := map[string]*MediaType{}
+	for _, mediaType := range consumes {
+		content[mediaType] = &MediaType{Schema: src.Schema}
+	}
+	c.setKeys(content, consumes)
+	return &RequestBody{
+		Description: src.Description,
+		Content:     content,
+		Required:    src.Required,
+		Extension:   swaggerExtension(src.Extension),
+	}
+}
+
+// formBody converts the form data parameters into request body
+// object, whose schema has the parameters as its properties.
+func (c *swaggerConverter) formBody(form []*swaggerParameter, consumes []string) *RequestBody {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	names := make([]string, len(form))
+	required := false
+	hasFile := false
+	for i, parameter := range form {
+		property := parameter.Primitive.schema()
+		property.Description = parameter.Description
+		schema.Properties[parameter.Name] = property
+		names[i] = parameter.Name
+		if parameter.Required {
+			schema.Required = append(schema.Required, parameter.Name)
+			required = true
+		}
+		if parameter.Primitive.Type == "file" {
+			hasFile = true
+		}
+	}
+	c.setKeys(schema.Properties, names)
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		} else {
+			mediaTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+	content := map[string]*MediaType{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = &MediaType{Schema: schema}
+	}
+	c.setKeys(content, mediaTypes)
+	return &RequestBody{Content: content, Required: required}
+}
+
+func (c *swaggerConverter) responses(src swaggerResponses, produces []string) Responses {
+	if src == nil {
+		return nil
+	}
+	responses := Responses{}
+	for status, response := range src {
+		responses[status] = c.response(response, produces)
+	}
+	c.copyKeys(responses, src)
+	c.copyExtension(responses, src)
+	return responses
+}
+
+func (c *swaggerConverter) response(src *swaggerResponse, produces []string) *Response {
+	if src.Ref != "" {
+		return &Response{Ref: src.Ref}
+	}
+	response := &Response{
+		Description: src.Description,
+		Extension:   swaggerExtension(src.Extension),
+	}
+	if len(src.Headers) > 0 {
+		response.Headers = map[string]*Header{}
+		for name, header := range src.Headers {
+			response.Headers[name] = &Header{
+				Description: header.Description,
+				Schema:      header.Primitive.schema(),
+				Extension:   swaggerExtension(header.Extension),
+			}
+		}
+		c.copyKeys(response.Headers, src.Headers)
+	}
+	if src.Schema == nil && len(src.Examples) == 0 {
+		return response
+	}
+	schema := src.Schema
+	if schema != nil && schema.Type == "file" {
+		schema = &Schema{Type: "string", Format: "binary"}
+	}
+	mediaTypes := append([]string{}, produces...)
+	for _, mediaType := range c.keys(src.Examples) {
+		if !containsString(mediaTypes, mediaType) {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	response.Content = map[string]*MediaType{}
+	for _, mediaType := range mediaTypes {
+		response.Content[mediaType] = &MediaType{
+			Schema:  schema,
+			Example: src.Examples[mediaType],
+		}
+	}
+	c.setKeys(response.Content, mediaTypes)
+	return response
+}
+
+func (c *swaggerConverter) securityScheme(src *swaggerSecurityScheme) *SecurityScheme {
+	secScheme := &SecurityScheme{
+		Description: src.Description,
+		Extension:   swaggerExtension(src.Extension),
+	}
+	switch src.Type {
+	case "basic":
+		secScheme.Type, secScheme.Scheme = HTTPType, "basic"
+	case "apiKey":
+		secScheme.Type, secScheme.Name, secScheme.In = APIKeyType, src.Name, src.In
+	case "oauth2":
+		secScheme.Type = OAuth2Type
+		scopes := src.Scopes
+		if scopes == nil {
+			scopes = map[string]string{}
+		}
+		flow := &OAuthFlow{Scopes: scopes}
+		flows := &OAuthFlows{}
+		switch src.Flow {
+		case "implicit":
+			flow.AuthorizationURL = src.AuthorizationURL
+			flows.Implicit = flow
+		case "password":
+			flow.TokenURL = src.TokenURL
+			flows.Password = flow
+		case "application":
+			flow.TokenURL = src.TokenURL
+			flows.ClientCredentials = flow
+		case "accessCode":
+			flow.AuthorizationURL = src.AuthorizationURL
+			flow.TokenURL = src.TokenURL
+			flows.AuthorizationCode = flow
+		}
+		secScheme.Flows = flows
+	default:
+		secScheme.Type = SecuritySchemeType(src.Type)
+	}
+	return secScheme
+}
+
+// schema converts the type of the parameter or the header into schema
+// object. The file type is converted into binary string.
+func (items *swaggerItems) schema() *Schema {
+	if items == nil {
+		return nil
+	}
+	schema := &Schema{
+		Type:             items.Type,
+		Format:           items.Format,
+		Items:            items.Items.schema(),
+		Default:          items.Default,
+		Maximum:          items.Maximum,
+		ExclusiveMaximum: items.ExclusiveMaximum,
+		Minimum:          items.Minimum,
+		ExclusiveMinimum: items.ExclusiveMinimum,
+		MaxLength:        items.MaxLength,
+		MinLength:        items.MinLength,
+		Pattern:          items.Pattern,
+		MaxItems:         items.MaxItems,
+		MinItems:         items.MinItems,
+		UniqueItems:      items.UniqueItems,
+		Enum:             items.Enum,
+		MultipleOf:       items.MultipleOf,
+	}
+	if schema.Type == "file" {
+		schema.Type, schema.Format = "string", "binary"
+	}
+	return schema
+}
+
+// keys returns the keys of the source map in the source order.
+func (c *swaggerConverter) keys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.IsNil() {
+		return nil
+	}
+	keys := c.maps.mapKeys(v)
+	ret := make([]string, len(keys))
+	for i, key := range keys {
+		ret[i] = key.String()
+	}
+	return ret
+}
+
+// copyKeys records the order of the keys of the source map src as the
+// one of the converted map dst.
+func (c *swaggerConverter) copyKeys(dst, src interface{}) {
+	c.setKeys(dst, c.keys(src))
+}
+
+// copyExtension sets the specification extensions of the source map
+// object to the converted one dst.
+func (c *swaggerConverter) copyExtension(dst, src interface{}) {
+	v := reflect.ValueOf(src)
+	if v.IsNil() {
+		return
+	}
+	info, ok := c.maps[v.Pointer()]
+	if !ok {
+		return
+	}
+	for name, value := range info.extension {
+		c.doc.SetMapExtension(dst, name, value)
+	}
+}
+
+// setKeys records the order of the keys of the converted map m.
+func (c *swaggerConverter) setKeys(m interface{}, keys []string) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.IsNil() {
+		return
+	}
+	ordered := make([]interface{}, len(keys))
+	for i, key := range keys {
+		ordered[i] = key
+	}
+	c.maps.recordKeys(v, ordered)
+}
+
+// swaggerExtension returns the specification extensions in the inline
+// map, which holds the other unknown fields too.
+func swaggerExtension(m map[string]interface{}) map[string]interface{} {
+	var ext map[string]interface{}
+	for k, v := range m {
+		if !strings.HasPrefix(k, extensionPrefix) {
+			continue
+		}
+		if ext == nil {
+			ext = map[string]interface{}{}
+		}
+		ext[k] = v
+	}
+	return ext
+}
+
+var swaggerRefPrefixes = []struct {
+	from, to string
+}{
+	{"/definitions/", "/components/schemas/"},
+	{"/parameters/", "/components/parameters/"},
+	{"/responses/", "/components/responses/"},
+}
+
+// convertSwaggerRef rewrites the JSON pointer in the local reference
+// to point the object in the converted document. The references to the
+// other documents are kept, as those documents are not converted.
+func convertSwaggerRef(ref string) string {
+	if !strings.HasPrefix(ref, "#") {
+		return ref
+	}
+	fragment := ref[1:]
+	for _, prefix := range swaggerRefPrefixes {
+		if strings.HasPrefix(fragment, prefix.from) {
+			return "#" + prefix.to + strings.TrimPrefix(fragment, prefix.from)
+		}
+	}
+	return ref
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,764 @@
+package openapi
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Swagger is a Swagger 2.0 spec exported from a document.
+type Swagger struct {
+	swagger *swagger
+	maps    mapInfos
+}
+
+// MarshalYAML implements yaml.Marshaler of gopkg.in/yaml.v3.
+// The fields with empty value are omitted, and the keys of the maps
+// are ordered as in the source document.
+func (s Swagger) MarshalYAML() (interface{}, error) {
+	e := &encoder{doc: &Document{maps: s.maps}}
+	node, err := e.encodeStruct(reflect.ValueOf(*s.swagger))
+	if err != nil {
+		return nil, err
+	}
+	exportSwaggerDiscriminator(node)
+	return node, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The fields with empty value are omitted.
+func (s Swagger) MarshalJSON() ([]byte, error) {
+	node, err := s.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, node.(*yaml.Node)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile writes the spec to the file. The spec is written as JSON
+// if the file name has .json extension, otherwise as YAML.
+func (s Swagger) WriteFile(filename string) error {
+	return writeFile(filename, s)
+}
+
+// exportSwaggerDiscriminator rewrites the discriminator objects into
+// the property names, as Swagger 2.0 has no discriminator object.
+func exportSwaggerDiscriminator(node *yaml.Node) {
+	eachSwaggerSchema(node, func(schema *yaml.Node) {
+		for i := 0; i+1 < len(schema.Content); i += 2 {
+			key, value := schema.Content[i], schema.Content[i+1]
+			if key.Value != "discriminator" || value.Kind != yaml.MappingNode {
+				continue
+			}
+			if propertyName := mappingValue(value, "propertyName"); propertyName != nil {
+				schema.Content[i+1] = propertyName
+			}
+		}
+	})
+}
+
+// ExportSwagger converts the document into Swagger 2.0 spec.
+// The features which cannot be represented in Swagger 2.0, like
+// oneOf, callbacks, links, cookie parameters or multiple servers, are
+// dropped and returned as warnings with their locations in the
+// document. The warnings are nil if all the features are converted.
+func (doc *Document) ExportSwagger() (*Swagger, ErrorList) {
+	x := &swaggerExporter{
+		doc:           doc,
+		v:             &validator{},
+		maps:          mapInfos{},
+		schemas:       map[*Schema]*Schema{},
+		requestBodies: map[string]string{},
+		dropped:       map[string]struct{}{},
+	}
+	x.maps.merge(doc.maps)
+	s := x.export()
+	if len(x.v.errs) == 0 {
+		return &Swagger{swagger: s, maps: x.maps}, nil
+	}
+	doc.locate(x.v.errs)
+	return &Swagger{swagger: s, maps: x.maps}, x.v.errs
+}
+
+// swaggerExporter converts OpenAPI 3.0 document into Swagger 2.0.
+type swaggerExporter struct {
+	doc *Document
+	// v holds the warnings.
+	v *validator
+	// maps holds the information of the maps in the exported spec.
+	maps mapInfos
+	// schemas holds the converted schemas keyed by the source ones, to
+	// convert the shared or circular schemas once.
+	schemas map[*Schema]*Schema
+	// requestBodies holds the names of the parameters which the request
+	// bodies in components are exported as.
+	requestBodies map[string]string
+	// dropped holds the names of the security schemes which cannot be
+	// exported.
+	dropped map[string]struct{}
+
+	host     string
+	basePath string
+}
+
+func (x *swaggerExporter) warn(ptr, name string) {
+	x.v.report(ptr, ErrSwaggerIncompatible{Name: name})
+}
+
+func (x *swaggerExporter) export() *swagger {
+	doc := x.doc
+	s := &swagger{
+		Swagger:      "2.0",
+		Info:         doc.Info,
+		Paths:        map[string]*swaggerPathItem{},
+		Tags:         doc.Tags,
+		ExternalDocs: doc.ExternalDocs,
+		Extension:    doc.Extension,
+	}
+	var schemes []string
+	x.host, x.basePath, schemes = x.servers(doc.Servers, "/servers")
+	s.Host, s.BasePath, s.Schemes = x.host, x.basePath, schemes
+	if s.BasePath == "/" {
+		s.BasePath = ""
+	}
+	if doc.Components != nil {
+		x.components(s, doc.Components)
+	}
+	s.Security = x.security(doc.Security)
+	for _, path := range doc.PathKeys() {
+		s.Paths[path] = x.pathItem(doc.Paths[path], joinPointer("", "paths", path))
+	}
+	x.copyKeys(s.Paths, doc.Paths)
+	if len(doc.Webhooks) > 0 {
+		x.warn("/webhooks", "webhooks")
+	}
+	return s
+}
+
+// servers returns host, basePath and schemes of the servers. The
+// servers with different host or base path from the first one cannot
+// be represented.
+func (x *swaggerExporter) servers(servers []*Server, ptr string) (host, basePath string, schemes []string) {
+	for i, server := range servers {
+		u, err := url.Parse(server.defaultURL())
+		if err != nil {
+			x.warn(joinPointer(ptr, strconv.Itoa(i)), "server")
+			continue
+		}
+		if i == 0 {
+			host, basePath = u.Host, u.Path
+		} else if u.Host != host || u.Path != basePath {
+			x.warn(joinPointer(ptr, strconv.Itoa(i)), "multiple servers")
+			continue
+		}
+		if u.Scheme != "" && !containsString(schemes, u.Scheme) {
+			schemes = append(schemes, u.Scheme)
+		}
+	}
+	return host, basePath, schemes
+}
+
+func (x *swaggerExporter) components(s *swagger, components *Components) {
+	ptr := "/components"
+	if len(components.Schemas) > 0 {
+		s.Definitions = map[string]*Schema{}
+		for name, schema := range components.Schemas {
+			s.Definitions[name] = x.schema(schema, joinPointer(ptr, "schemas", name))
+		}
+		x.copyKeys(s.Definitions, components.Schemas)
+	}
+	var parameterKeys []string
+	for _, name := range x.doc.Keys(components.Parameters) {
+		parameter := x.parameter(components.Parameters[name], joinPointer(ptr, "parameters", name))
+		if parameter == nil {
+			continue
+		}
+		if s.Parameters == nil {
+			s.Parameters = map[string]*swaggerParameter{}
+		}
+		s.Parameters[name] = parameter
+		parameterKeys = append(parameterKeys, name)
+	}
+	for _, name := range x.doc.Keys(components.RequestBodies) {
+		if _, ok := components.Parameters[name]; ok {
+			continue // inlined into the operations not to collide
+		}
+		parameters, _ := x.requestBody(components.RequestBodies[name], joinPointer(ptr, "requestBodies", name))
+		if len(parameters) != 1 || parameters[0].In != "body" {
+			continue // form data is inlined into the operations
+		}
+		if s.Parameters == nil {
+			s.Parameters = map[string]*swaggerParameter{}
+		}
+		parameters[0].Name = name
+		s.Parameters[name] = parameters[0]
+		parameterKeys = append(parameterKeys, name)
+		x.requestBodies[name] = name
+	}
+	x.setKeys(s.Parameters, parameterKeys)
+	if len(components.Responses) > 0 {
+		s.Responses = map[string]*swaggerResponse{}
+		for name, response := range components.Responses {
+			s.Responses[name], _ = x.response(response, joinPointer(ptr, "responses", name))
+		}
+		x.copyKeys(s.Responses, components.Responses)
+	}
+	var secSchemeKeys []string
+	for _, name := range x.doc.Keys(components.SecuritySchemes) {
+		secScheme := x.securityScheme(components.SecuritySchemes[name], joinPointer(ptr, "securitySchemes", name))
+		if secScheme == nil {
+			x.dropped[name] = struct{}{}
+			continue
+		}
+		if s.SecurityDefinitions == nil {
+			s.SecurityDefinitions = map[string]*swaggerSecurityScheme{}
+		}
+		s.SecurityDefinitions[name] = secScheme
+		secSchemeKeys = append(secSchemeKeys, name)
+	}
+	x.setKeys(s.SecurityDefinitions, secSchemeKeys)
+	if len(components.Examples) > 0 {
+		x.warn(joinPointer(ptr, "examples"), "examples in components")
+	}
+	if len(components.Links) > 0 {
+		x.warn(joinPointer(ptr, "links"), "links")
+	}
+	if len(components.Callbacks) > 0 {
+		x.warn(joinPointer(ptr, "callbacks"), "callbacks")
+	}
+	if len(components.PathItems) > 0 {
+		x.warn(joinPointer(ptr, "pathItems"), "path items in components")
+	}
+}
+
+func (x *swaggerExporter) pathItem(pathItem *PathItem, ptr string) *swaggerPathItem {
+	s := &swaggerPathItem{
+		Ref:       pathItem.Ref,
+		Extension: pathItem.Extension,
+	}
+	for i, parameter := range pathItem.Parameters {
+		if p := x.parameter(parameter, joinPointer(ptr, "parameters", strconv.Itoa(i))); p != nil {
+			s.Parameters = append(s.Parameters, p)
+		}
+	}
+	for _, op := range []struct {
+		method string
+		src    *Operation
+		dst    **swaggerOperation
+	}{
+		{"get", pathItem.Get, &s.Get},
+		{"put", pathItem.Put, &s.Put},
+		{"post", pathItem.Post, &s.Post},
+		{"delete", pathItem.Delete, &s.Delete},
+		{"options", pathItem.Options, &s.Options},
+		{"head", pathItem.Head, &s.Head},
+		{"patch", pathItem.Patch, &s.Patch},
+	} {
+		if op.src != nil {
+			*op.dst = x.operation(op.src, joinPointer(ptr, op.method))
+		}
+	}
+	if pathItem.Trace != nil {
+		x.warn(joinPointer(ptr, "trace"), "trace operation")
+	}
+	if len(pathItem.Servers) > 0 {
+		x.warn(joinPointer(ptr, "servers"), "servers of path item")
+	}
+	return s
+}
+
+func (x *swaggerExporter) operation(op *Operation, ptr string) *swaggerOperation {
+	s := &swaggerOperation{
+		Tags:         op.Tags,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: op.ExternalDocs,
+		OperationID:  op.OperationID,
+		Deprecated:   op.Deprecated,
+		Security:     x.security(op.Security),
+		Extension:    op.Extension,
+	}
+	for i, parameter := range op.Parameters {
+		if p := x.parameter(parameter, joinPointer(ptr, "parameters", strconv.Itoa(i))); p != nil {
+			s.Parameters = append(s.Parameters, p)
+		}
+	}
+	if op.RequestBody != nil {
+		parameters, consumes := x.requestBody(op.RequestBody, joinPointer(ptr, "requestBody"))
+		s.Parameters = append(s.Parameters, parameters...)
+		s.Consumes = consumes
+	}
+	if op.Responses != nil {
+		s.Responses = map[string]*swaggerResponse{}
+		for _, status := range x.doc.ResponseKeys(op.Responses) {
+			response, produces := x.response(op.Responses[status], joinPointer(ptr, "responses", status))
+			s.Responses[status] = response
+			for _, mediaType := range produces {
+				if !containsString(s.Produces, mediaType) {
+					s.Produces = append(s.Produces, mediaType)
+				}
+			}
+		}
+		x.copyKeys(s.Responses, op.Responses)
+	}
+	if len(op.Callbacks) > 0 {
+		x.warn(joinPointer(ptr, "callbacks"), "callbacks")
+	}
+	if len(op.Servers) > 0 {
+		host, basePath, schemes := x.servers(op.Servers, joinPointer(ptr, "servers"))
+		if host != x.host || basePath != x.basePath {
+			x.warn(joinPointer(ptr, "servers"), "servers of operation")
+		} else {
+			s.Schemes = schemes
+		}
+	}
+	return s
+}
+
+// parameter converts the parameter. If the parameter cannot be
+// represented, it returns nil.
+func (x *swaggerExporter) parameter(parameter *Parameter, ptr string) *swaggerParameter {
+	if parameter.Ref != "" {
+		resolved, err := resolveChain(x.doc, parameter.Ref, parameter)
+		if err == nil && resolved.(*Parameter).In == InCookie {
+			x.warn(ptr, "cookie parameter")
+			return nil
+		}
+		return &swaggerParameter{Ref: exportSwaggerRef(parameter.Ref)}
+	}
+	if parameter.In == InCookie {
+		x.warn(ptr, "cookie parameter")
+		return nil
+	}
+	s := &swaggerParameter{
+		Name:            parameter.Name,
+		In:              string(parameter.In),
+		Description:     parameter.Description,
+		Required:        parameter.Required,
+		AllowEmptyValue: parameter.AllowEmptyValue,
+		Extension:       parameter.Extension,
+	}
+	if len(parameter.Examples) > 0 {
+		x.warn(joinPointer(ptr, "examples"), "examples of parameter")
+	}
+	schema, schemaPtr := parameter.Schema, joinPointer(ptr, "schema")
+	if schema == nil && len(parameter.Content) > 0 {
+		x.warn(joinPointer(ptr, "content"), "content of parameter")
+		mediaType := x.doc.Keys(parameter.Content)[0]
+		schema, schemaPtr = parameter.Content[mediaType].Schema, joinPointer(ptr, "content", mediaType, "schema")
+	}
+	s.Primitive = x.items(schema, schemaPtr)
+	if s.Primitive.Type == "array" {
+		switch parameter.Style {
+		case "spaceDelimited":
+			s.Primitive.CollectionFormat = "ssv"
+		case "pipeDelimited":
+			s.Primitive.CollectionFormat = "pipes"
+		case "deepObject":
+			x.warn(joinPointer(ptr, "style"), "deepObject style")
+		default:
+			if parameter.In == InQuery && parameter.explode() {
+				s.Primitive.CollectionFormat = "multi"
+			}
+		}
+	}
+	return s
+}
+
+// items converts the schema of the parameter or the header, which can
+// be only a primitive type or an array of them.
+func (x *swaggerExporter) items(schema *Schema, ptr string) swaggerItems {
+	if schema == nil {
+		return swaggerItems{}
+	}
+	if schema.Ref != "" {
+		resolved, err := resolveChain(x.doc, schema.Ref, schema)
+		if err != nil {
+			x.warn(ptr, "unresolved schema")
+			return swaggerItems{}
+		}
+		schema = resolved.(*Schema)
+	}
+	items := swaggerItems{
+		Type:             schema.Type,
+		Format:           schema.Format,
+		Default:          schema.Default,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		Enum:             schema.Enum,
+		MultipleOf:       schema.MultipleOf,
+	}
+	switch schema.Type {
+	case "object", "":
+		x.warn(ptr, "non-primitive schema of parameter or header")
+	case "array":
+		if schema.Items != nil {
+			child := x.items(schema.Items, joinPointer(ptr, "items"))
+			items.Items = &child
+		}
+	}
+	return items
+}
+
+// requestBody converts the request body into a body parameter or form
+// data parameters, and returns them with their media types.
+func (x *swaggerExporter) requestBody(requestBody *RequestBody, ptr string) ([]*swaggerParameter, []string) {
+	if requestBody.Ref != "" {
+		resolved, err := resolveChain(x.doc, requestBody.Ref, requestBody)
+		if err != nil {
+			x.warn(ptr, "unresolved request body")
+			return nil, nil
+		}
+		if name, ok := x.requestBodies[strings.TrimPrefix(requestBody.Ref, "#/components/requestBodies/")]; ok {
+			consumes := x.doc.Keys(resolved.(*RequestBody).Content)
+			return []*swaggerParameter{{Ref: "#/parameters/" + name}}, consumes
+		}
+		requestBody = resolved.(*RequestBody)
+	}
+	var form, body []string
+	for _, mediaType := range x.doc.Keys(requestBody.Content) {
+		if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+			form = append(form, mediaType)
+		} else {
+			body = append(body, mediaType)
+		}
+	}
+	if len(body) == 0 && len(form) > 0 {
+		mediaType := form[0]
+		return x.formData(requestBody.Content[mediaType].Schema, joinPointer(ptr, "content", mediaType, "schema")), form
+	}
+	for _, mediaType := range form {
+		x.warn(joinPointer(ptr, "content", mediaType), "form data with other media types")
+	}
+	parameter := &swaggerParameter{
+		Name:        "body",
+		In:          "body",
+		Description: requestBody.Description,
+		Required:    requestBody.Required,
+		Extension:   requestBody.Extension,
+	}
+	for i, mediaType := range body {
+		x.mediaTypeExamples(requestBody.Content[mediaType], joinPointer(ptr, "content", mediaType))
+		schema := requestBody.Content[mediaType].Schema
+		if i == 0 {
+			parameter.Schema = x.schema(schema, joinPointer(ptr, "content", mediaType, "schema"))
+			continue
+		}
+		if !sameSchema(schema, requestBody.Content[body[0]].Schema) {
+			x.warn(joinPointer(ptr, "content", mediaType, "schema"), "multiple schemas of request body")
+		}
+	}
+	return []*swaggerParameter{parameter}, body
+}
+
+// formData converts the properties of the schema into form data
+// parameters.
+func (x *swaggerExporter) formData(schema *Schema, ptr string) []*swaggerParameter {
+	if schema != nil && schema.Ref != "" {
+		resolved, err := resolveChain(x.doc, schema.Ref, schema)
+		if err != nil {
+			x.warn(ptr, "unresolved schema")
+			return nil
+		}
+		schema = resolved.(*Schema)
+	}
+	if schema == nil {
+		return nil
+	}
+	var parameters []*swaggerParameter
+	for _, name := range x.doc.PropertyKeys(schema) {
+		property := schema.Properties[name]
+		parameter := &swaggerParameter{
+			Name:        name,
+			In:          "formData",
+			Description: property.Description,
+			Required:    containsString(schema.Required, name),
+			Primitive:   x.items(property, joinPointer(ptr, "properties", name)),
+		}
+		if parameter.Primitive.Type == "string" && parameter.Primitive.Format == "binary" {
+			parameter.Primitive.Type, parameter.Primitive.Format = "file", ""
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// response converts the response, and returns it with its media types.
+func (x *swaggerExporter) response(response *Response, ptr string) (*swaggerResponse, []string) {
+	if response.Ref != "" {
+		var produces []string
+		if resolved, err := resolveChain(x.doc, response.Ref, response); err == nil {
+			produces = x.doc.Keys(resolved.(*Response).Content)
+		}
+		return &swaggerResponse{Ref: exportSwaggerRef(response.Ref)}, produces
+	}
+	s := &swaggerResponse{
+		Description: response.Description,
+		Extension:   response.Extension,
+	}
+	if len(response.Headers) > 0 {
+		s.Headers = map[string]*swaggerHeader{}
+		for name, header := range response.Headers {
+			headerPtr := joinPointer(ptr, "headers", name)
+			if header.Ref != "" {
+				resolved, err := resolveChain(x.doc, header.Ref, header)
+				if err != nil {
+					x.warn(headerPtr, "unresolved header")
+					continue
+				}
+				header = resolved.(*Header)
+			}
+			if len(header.Examples) > 0 {
+				x.warn(joinPointer(headerPtr, "examples"), "examples of header")
+			}
+			s.Headers[name] = &swaggerHeader{
+				Description: header.Description,
+				Primitive:   x.items(header.Schema, joinPointer(headerPtr, "schema")),
+				Extension:   header.Extension,
+			}
+		}
+		x.copyKeys(s.Headers, response.Headers)
+	}
+	produces := x.doc.Keys(response.Content)
+	var examples []string
+	var schema *Schema
+	for _, mediaType := range produces {
+		mt := response.Content[mediaType]
+		x.mediaTypeExamples(mt, joinPointer(ptr, "content", mediaType))
+		switch {
+		case mt.Schema == nil:
+		case schema == nil:
+			schema = mt.Schema
+			s.Schema = x.schema(mt.Schema, joinPointer(ptr, "content", mediaType, "schema"))
+		case !sameSchema(mt.Schema, schema):
+			x.warn(joinPointer(ptr, "content", mediaType, "schema"), "multiple schemas of response")
+		}
+		if mt.Example != nil {
+			if s.Examples == nil {
+				s.Examples = map[string]interface{}{}
+			}
+			s.Examples[mediaType] = mt.Example
+			examples = append(examples, mediaType)
+		}
+	}
+	x.setKeys(s.Examples, examples)
+	if len(response.Links) > 0 {
+		x.warn(joinPointer(ptr, "links"), "links")
+	}
+	return s, produces
+}
+
+// sameSchema reports whether the schemas of the media types are same,
+// as the ones decoded from the same definition are not shared.
+func sameSchema(a, b *Schema) bool {
+	return a == b || reflect.DeepEqual(a, b)
+}
+
+// mediaTypeExamples warns the named examples of the media type, which
+// Swagger 2.0 does not have.
+func (x *swaggerExporter) mediaTypeExamples(mediaType *MediaType, ptr string) {
+	if mediaType != nil && len(mediaType.Examples) > 0 {
+		x.warn(joinPointer(ptr, "examples"), "examples of media type")
+	}
+}
+
+// schema copies the schema, rewriting the references and dropping the
+// keywords which Swagger 2.0 does not have.
+func (x *swaggerExporter) schema(schema *Schema, ptr string) *Schema {
+	if schema == nil {
+		return nil
+	}
+	if copied, ok := x.schemas[schema]; ok {
+		return copied
+	}
+	s := *schema
+	x.schemas[schema] = &s
+	s.Ref = exportSwaggerRef(s.Ref)
+	if len(s.OneOf) > 0 {
+		x.warn(joinPointer(ptr, "oneOf"), "oneOf")
+		s.OneOf = nil
+	}
+	if len(s.AnyOf) > 0 {
+		x.warn(joinPointer(ptr, "anyOf"), "anyOf")
+		s.AnyOf = nil
+	}
+	if s.Not != nil {
+		x.warn(joinPointer(ptr, "not"), "not")
+		s.Not = nil
+	}
+	if s.WriteOnly {
+		x.warn(joinPointer(ptr, "writeOnly"), "writeOnly")
+		s.WriteOnly = false
+	}
+	if s.Deprecated {
+		x.warn(joinPointer(ptr, "deprecated"), "deprecated schema")
+		s.Deprecated = false
+	}
+	if s.Discriminator != nil && len(s.Discriminator.Mapping) > 0 {
+		x.warn(joinPointer(ptr, "discriminator", "mapping"), "discriminator mapping")
+	}
+	if s.Nullable {
+		s.Nullable = false
+		s.Extension = copyExtension(s.Extension)
+		s.Extension["x-nullable"] = true
+	}
+	if s.AllOf != nil {
+		s.AllOf = make([]*Schema, len(schema.AllOf))
+		for i, child := range schema.AllOf {
+			s.AllOf[i] = x.schema(child, joinPointer(ptr, "allOf", strconv.Itoa(i)))
+		}
+	}
+	s.Items = x.schema(schema.Items, joinPointer(ptr, "items"))
+	s.AdditionalProperties = x.schema(schema.AdditionalProperties, joinPointer(ptr, "additionalProperties"))
+	if schema.Properties != nil {
+		s.Properties = make(map[string]*Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			s.Properties[name] = x.schema(property, joinPointer(ptr, "properties", name))
+		}
+		x.copyKeys(s.Properties, schema.Properties)
+	}
+	return &s
+}
+
+func (x *swaggerExporter) securityScheme(secScheme *SecurityScheme, ptr string) *swaggerSecurityScheme {
+	if secScheme.Ref != "" {
+		resolved, err := resolveChain(x.doc, secScheme.Ref, secScheme)
+		if err != nil {
+			x.warn(ptr, "unresolved security scheme")
+			return nil
+		}
+		secScheme = resolved.(*SecurityScheme)
+	}
+	s := &swaggerSecurityScheme{
+		Description: secScheme.Description,
+		Extension:   secScheme.Extension,
+	}
+	switch secScheme.Type {
+	case APIKeyType:
+		if secScheme.In == InCookie {
+			x.warn(joinPointer(ptr, "in"), "cookie API key")
+			return nil
+		}
+		s.Type, s.Name, s.In = "apiKey", secScheme.Name, secScheme.In
+	case HTTPType:
+		if !strings.EqualFold(secScheme.Scheme, "basic") {
+			x.warn(joinPointer(ptr, "scheme"), "HTTP authentication scheme other than basic")
+			return nil
+		}
+		s.Type = "basic"
+	case OAuth2Type:
+		s.Type = "oauth2"
+		if secScheme.Flows == nil {
+			return s
+		}
+		flows := secScheme.Flows
+		for _, flow := range []struct {
+			name, flow string
+			src        *OAuthFlow
+		}{
+			{"implicit", "implicit", flows.Implicit},
+			{"password", "password", flows.Password},
+			{"clientCredentials", "application", flows.ClientCredentials},
+			{"authorizationCode", "accessCode", flows.AuthorizationCode},
+		} {
+			if flow.src == nil {
+				continue
+			}
+			if s.Flow != "" {
+				x.warn(joinPointer(ptr, "flows", flow.name), "multiple OAuth flows")
+				continue
+			}
+			s.Flow = flow.flow
+			s.AuthorizationURL = flow.src.AuthorizationURL
+			s.TokenURL = flow.src.TokenURL
+			s.Scopes = flow.src.Scopes
+		}
+	default:
+		x.warn(joinPointer(ptr, "type"), string(secScheme.Type)+" security scheme")
+		return nil
+	}
+	return s
+}
+
+// security drops the security schemes which cannot be exported from
+// the requirements.
+func (x *swaggerExporter) security(requirements []*SecurityRequirement) []*SecurityRequirement {
+	if requirements == nil || len(x.dropped) == 0 {
+		return requirements
+	}
+	ret := []*SecurityRequirement{}
+	for _, requirement := range requirements {
+		mp := map[string][]string{}
+		for name, scopes := range requirement.requirements() {
+			if _, ok := x.dropped[name]; !ok {
+				mp[name] = scopes
+			}
+		}
+		if len(mp) == 0 && len(requirement.requirements()) > 0 {
+			continue
+		}
+		ret = append(ret, &SecurityRequirement{mp: mp})
+	}
+	return ret
+}
+
+// copyKeys records the order of the keys of the source map src as the
+// one of the exported map dst.
+func (x *swaggerExporter) copyKeys(dst, src interface{}) {
+	x.setKeys(dst, x.doc.Keys(src))
+}
+
+// setKeys records the order of the keys of the exported map m.
+func (x *swaggerExporter) setKeys(m interface{}, keys []string) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.IsNil() {
+		return
+	}
+	ordered := make([]interface{}, len(keys))
+	for i, key := range keys {
+		ordered[i] = key
+	}
+	x.maps.recordKeys(v, ordered)
+}
+
+// exportSwaggerRef rewrites the JSON pointer in the reference to point
+// the object in the exported spec.
+func exportSwaggerRef(ref string) string {
+	idx := strings.IndexByte(ref, '#')
+	if idx < 0 {
+		return ref
+	}
+	base, fragment := ref[:idx+1], ref[idx+1:]
+	for _, prefix := range swaggerRefPrefixes {
+		if strings.HasPrefix(fragment, prefix.to) {
+			return base + prefix.from + strings.TrimPrefix(fragment, prefix.to)
+		}
+	}
+	return ref
+}
+
+// copyExtension returns a copy of the specification extensions, which
+// is never nil.
+func copyExtension(ext map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(ext))
+	for k, v := range ext {
+		ret[k] = v
+	}
+	return ret
+}
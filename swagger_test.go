@@ -0,0 +1,409 @@
+package openapi_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestLoadSwaggerFile(t *testing.T) {
+	doc, err := openapi.LoadSwaggerFile("testdata/swagger/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := openapi.LoadFile("testdata/swagger/petstore.openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := yaml.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("unexpected document:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLoadSwagger(t *testing.T) {
+	doc, err := openapi.LoadSwagger([]byte(`{
+  "swagger": "2.0",
+  "info": {"title": "json", "version": "1.0.0"},
+  "basePath": "/api",
+  "paths": {
+    "/users": {
+      "parameters": [
+        {"name": "user", "in": "body", "schema": {"$ref": "user.yaml#/definitions/User"}}
+      ],
+      "post": {
+        "responses": {"201": {"description": "created"}}
+      },
+      "put": {
+        "consumes": ["application/xml"],
+        "parameters": [
+          {"name": "user", "in": "body", "required": true, "schema": {"type": "object"}},
+          {"name": "ids", "in": "query", "type": "array", "items": {"type": "integer"}}
+        ],
+        "responses": {"204": {"description": "updated"}}
+      }
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*openapi.Server{{URL: "/api"}}; !reflect.DeepEqual(doc.Servers, want) {
+		t.Errorf("unexpected servers: %v", doc.Servers)
+	}
+	pathItem := doc.Paths["/users"]
+	if pathItem.Parameters != nil {
+		t.Errorf("body parameter should be moved into operations: %v", pathItem.Parameters)
+	}
+	post := pathItem.Post.RequestBody
+	if got := post.Content["application/json"].Schema.Ref; got != "user.yaml#/definitions/User" {
+		t.Errorf("unexpected reference: %s", got)
+	}
+	put := pathItem.Put.RequestBody
+	if _, ok := put.Content["application/xml"]; !ok || !put.Required {
+		t.Errorf("operation parameter should override path item one: %+v", put)
+	}
+	ids := pathItem.Put.Parameters[0]
+	if ids.Style != "form" || ids.Explode == nil || *ids.Explode || ids.Schema.Items.Type != "integer" {
+		t.Errorf("unexpected parameter: %+v", ids)
+	}
+}
+
+func TestLoadSwagger_MapExtension(t *testing.T) {
+	doc, err := openapi.LoadSwagger([]byte(`swagger: '2.0'
+info:
+  title: extended
+  version: 1.0.0
+paths:
+  x-internal: true
+  x-owner:
+    name: pets team
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+        x-cache:
+          ttl: 60
+responses:
+  x-shared: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Paths) != 1 {
+		t.Errorf("extension of paths should not be a path: %v", doc.PathKeys())
+	}
+	want := map[string]interface{}{"x-internal": true, "x-owner": map[string]interface{}{"name": "pets team"}}
+	if got := doc.MapExtension(doc.Paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected extension of paths: %v", got)
+	}
+	responses := doc.Paths["/pets"].Get.Responses
+	if len(responses) != 1 {
+		t.Errorf("extension of responses should not be a response: %v", doc.ResponseKeys(responses))
+	}
+	want = map[string]interface{}{"x-cache": map[string]interface{}{"ttl": 60}}
+	if got := doc.MapExtension(responses); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected extension of responses: %v", got)
+	}
+	if doc.Components != nil {
+		t.Errorf("extension of responses definitions should not be a response: %+v", doc.Components)
+	}
+}
+
+func TestLoadSwagger_Discriminator(t *testing.T) {
+	doc, err := openapi.LoadSwagger([]byte(`swagger: '2.0'
+info:
+  title: discriminator
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          schema:
+            type: array
+            items:
+              discriminator: kind
+              x-example:
+                discriminator: dog
+definitions:
+  Pet:
+    type: object
+    discriminator: petType
+    properties:
+      owner:
+        allOf:
+          - discriminator: ownerType
+      x-note:
+        discriminator: noteType
+    example:
+      discriminator: cat
+    default:
+      discriminator: cat
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := doc.Components.Schemas["Pet"]
+	candidates := []struct {
+		label    string
+		schema   *openapi.Schema
+		expected string
+	}{
+		{"definition", pet, "petType"},
+		{"subschema", pet.Properties["owner"].AllOf[0], "ownerType"},
+		{"property named like extension", pet.Properties["x-note"], "noteType"},
+		{"response schema", doc.Paths["/pets"].Get.Responses["200"].Content["application/json"].Schema.Items, "kind"},
+	}
+	for _, c := range candidates {
+		if c.schema.Discriminator == nil || c.schema.Discriminator.PropertyName != c.expected {
+			t.Errorf("%s: unexpected discriminator: %+v", c.label, c.schema.Discriminator)
+		}
+	}
+	want := map[string]interface{}{"discriminator": "cat"}
+	if !reflect.DeepEqual(pet.Example, want) || !reflect.DeepEqual(pet.Default, want) {
+		t.Errorf("example and default should not be rewritten: %v, %v", pet.Example, pet.Default)
+	}
+	items := doc.Paths["/pets"].Get.Responses["200"].Content["application/json"].Schema.Items
+	if got := items.Extension["x-example"]; !reflect.DeepEqual(got, map[string]interface{}{"discriminator": "dog"}) {
+		t.Errorf("extension should not be rewritten: %v", got)
+	}
+}
+
+func TestLoadSwagger_UnsupportedVersion(t *testing.T) {
+	b, err := os.ReadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openapi.LoadSwagger(b); err != openapi.ErrUnsupportedVersion {
+		t.Errorf("%v != %v", err, openapi.ErrUnsupportedVersion)
+	}
+}
+
+func TestDocument_ExportSwagger(t *testing.T) {
+	doc, err := openapi.LoadFile("testdata/swagger/petstore.openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, warnings := doc.ExportSwagger()
+	if warnings != nil {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// converting back gives the same document
+	converted, err := openapi.LoadSwagger(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := yaml.Marshal(converted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("unexpected document:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDocument_ExportSwagger_Warnings(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: warnings
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: us
+  - url: http://us.example.com/v1
+  - url: https://eu.example.com/v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: session
+          in: cookie
+          schema:
+            type: string
+          examples:
+            guest:
+              value: guest
+      responses:
+        '200':
+          description: ok
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+              examples:
+                low:
+                  value: 1
+          content:
+            application/json:
+              schema:
+                oneOf:
+                  - type: string
+                  - type: integer
+              examples:
+                cat:
+                  value: tama
+            application/xml:
+              schema:
+                type: string
+          links:
+            next:
+              operationId: getPets
+      callbacks:
+        onEvent:
+          '{$request.query.url}':
+            post:
+              responses:
+                '200':
+                  description: ok
+    trace:
+      responses:
+        '200':
+          description: ok
+components:
+  examples:
+    Cat:
+      value: tama
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, warnings := doc.ExportSwagger()
+	want := []string{
+		"/servers/2",
+		"/paths/~1pets/get/parameters/0",
+		"/paths/~1pets/get/responses/200/content/application~1json/schema/oneOf",
+		"/paths/~1pets/get/responses/200/content/application~1json/examples",
+		"/paths/~1pets/get/responses/200/content/application~1xml/schema",
+		"/paths/~1pets/get/responses/200/headers/X-Rate-Limit/examples",
+		"/components/examples",
+		"/paths/~1pets/get/responses/200/links",
+		"/paths/~1pets/get/callbacks",
+		"/paths/~1pets/trace",
+		"/components/securitySchemes/bearer/scheme",
+	}
+	var got []string
+	for _, w := range warnings {
+		var incompatible openapi.ErrSwaggerIncompatible
+		if !errors.As(w, &incompatible) {
+			t.Errorf("unexpected warning: %v", w)
+		}
+		if w.Position.Line == 0 {
+			t.Errorf("warning should have the position: %v", w)
+		}
+		got = append(got, w.Pointer)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%v != %v", got, want)
+	}
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"host":"us.example.com","basePath":"/v1","schemes":["https","http"]`) {
+		t.Errorf("servers are not converted: %s", b)
+	}
+}
+
+func TestDocument_ExportSwagger_Discriminator(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.0.3
+info:
+  title: discriminator
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      discriminator:
+        propertyName: petType
+      properties:
+        owner:
+          allOf:
+            - discriminator:
+                propertyName: ownerType
+      example:
+        discriminator:
+          propertyName: cat
+      x-example:
+        discriminator:
+          propertyName: dog
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := doc.ExportSwagger()
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Definitions map[string]struct {
+			Discriminator interface{}
+			Properties    map[string]struct {
+				AllOf []struct {
+					Discriminator interface{}
+				} `yaml:"allOf"`
+			}
+			Example  map[string]interface{}
+			XExample map[string]interface{} `yaml:"x-example"`
+		}
+	}
+	if err := yaml.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	pet := got.Definitions["Pet"]
+	if pet.Discriminator != "petType" || pet.Properties["owner"].AllOf[0].Discriminator != "ownerType" {
+		t.Errorf("discriminators should be property names:\n%s", b)
+	}
+	want := map[string]interface{}{"propertyName": "cat"}
+	if !reflect.DeepEqual(pet.Example["discriminator"], want) {
+		t.Errorf("example should not be rewritten:\n%s", b)
+	}
+	want = map[string]interface{}{"propertyName": "dog"}
+	if !reflect.DeepEqual(pet.XExample["discriminator"], want) {
+		t.Errorf("extension should not be rewritten:\n%s", b)
+	}
+}
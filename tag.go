@@ -7,15 +7,27 @@ type Tag struct {
 	Name         string
 	Description  string
 	ExternalDocs *ExternalDocumentation `yaml:"externalDocs"`
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of Tag object.
 func (tag Tag) Validate() error {
+	return validateFirst(tag)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (tag Tag) GetExtension(name string, v interface{}) error {
+	return getExtension(tag.Extension, name, v)
+}
+
+func (tag Tag) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, tag.Extension)
 	if tag.Name == "" {
-		return ErrRequired{Target: "tag.name"}
+		v.report(ptr, ErrRequired{Target: "tag.name"})
 	}
 	if tag.ExternalDocs != nil {
-		return tag.ExternalDocs.Validate()
+		tag.ExternalDocs.validate(v, joinPointer(ptr, "externalDocs"))
 	}
-	return nil
 }
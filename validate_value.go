@@ -3,6 +3,7 @@ package openapi
 import (
 	"net/url"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -14,16 +15,72 @@ type validater interface {
 	Validate() error
 }
 
-func validateAll(vs []validater) error {
-	for _, v := range vs {
-		if v == nil {
-			continue
-		}
-		if err := v.Validate(); err != nil {
-			return err
-		}
+// pointerValidater is implemented by the objects which can report
+// all of their problems, with the JSON pointer to where they are found.
+type pointerValidater interface {
+	validate(v *validator, ptr string)
+}
+
+// validator collects the errors found while walking a document.
+type validator struct {
+	errs ErrorList
+	// version is the OAS version of the document, in "major.minor"
+	// form. It is empty if the object is validated by itself, and the
+	// checks depending on the version are skipped.
+	version string
+	// doc is the document being validated. It is nil if the object is
+	// validated by itself, and the checks which need to resolve the
+	// references, like the ones of the examples, are skipped.
+	doc *Document
+}
+
+func (v *validator) is30() bool {
+	return v.version == "3.0"
+}
+
+func (v *validator) is31() bool {
+	return v.version == "3.1"
+}
+
+// reportUnsupported reports that the field is not supported in the
+// version of the document.
+func (v *validator) reportUnsupported(ptr, name string) {
+	v.report(joinPointer(ptr, name), ErrUnsupportedField{Name: name, Version: v.version})
+}
+
+func (v *validator) report(ptr string, err error) {
+	v.errs = append(v.errs, LocatedError{Pointer: ptr, Err: err})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
 	}
-	return nil
+	return v.errs
+}
+
+// validateFirst validates given object and returns the error
+// which is found first.
+func validateFirst(pv pointerValidater) error {
+	v := &validator{}
+	pv.validate(v, "")
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs[0].Err
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// joinPointer appends given reference tokens to the JSON pointer.
+func joinPointer(ptr string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(ptr)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(token))
+	}
+	return b.String()
 }
 
 func mustURL(name, urlStr string) error {
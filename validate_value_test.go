@@ -1,7 +1,6 @@
 package openapi_test
 
 import (
-	"errors"
 	"reflect"
 	"strconv"
 	"testing"
@@ -31,38 +30,6 @@ func testValidater(t *testing.T, candidates []candidate) {
 	}
 }
 
-type mockValidater struct {
-	err error
-}
-
-func (v mockValidater) Validate() error {
-	return v.err
-}
-
-func TestValidateAll(t *testing.T) {
-	valid := mockValidater{}
-	invalid := mockValidater{errors.New("err")}
-
-	candidates := []struct {
-		label  string
-		in     []openapi.Validater
-		hasErr bool
-	}{
-		{"nil", nil, false},
-		{"empty", []openapi.Validater{}, false},
-		{"all valid", []openapi.Validater{valid, valid, valid}, false},
-		{"have invalid", []openapi.Validater{valid, invalid, valid}, true},
-		{"have nil", []openapi.Validater{valid, nil, valid, valid}, false},
-	}
-	for i, c := range candidates {
-		t.Run(strconv.Itoa(i)+"/"+c.label, func(t *testing.T) {
-			if err := openapi.ValidateAll(c.in); (err != nil) != c.hasErr {
-				t.Errorf("error: %s", err)
-			}
-		})
-	}
-}
-
 func TestMustURL(t *testing.T) {
 	candidates := []struct {
 		label  string
@@ -88,3 +55,24 @@ func TestMustURL(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinPointer(t *testing.T) {
+	candidates := []struct {
+		label  string
+		ptr    string
+		tokens []string
+		want   string
+	}{
+		{"root", "", nil, ""},
+		{"simple", "", []string{"paths"}, "/paths"},
+		{"slash", "/paths", []string{"/pets/{petId}", "get"}, "/paths/~1pets~1{petId}/get"},
+		{"tilde", "/components/schemas", []string{"a~b"}, "/components/schemas/a~0b"},
+	}
+	for i, c := range candidates {
+		t.Run(strconv.Itoa(i)+"/"+c.label, func(t *testing.T) {
+			if got := openapi.JoinPointer(c.ptr, c.tokens...); got != c.want {
+				t.Errorf("%s != %s", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidateValue validates the value against the schema, resolving the
+// references in doc. The value can be the one decoded from JSON, or
+// any Go value which can be encoded into JSON. All the violations are
+// returned as ErrorList, whose pointers are the JSON pointers to the
+// invalid parts of the value.
+// readOnly and writeOnly are not checked. To check them, use
+// ValidateRequestValue or ValidateResponseValue.
+func (schema Schema) ValidateValue(doc *Document, v interface{}) error {
+	return schema.validateValue(doc, v, anyContext)
+}
+
+// ValidateRequestValue validates the value sent in a request. In
+// addition to ValidateValue, the readOnly properties must not be in the
+// value, and they are not required even if they are listed in
+// required.
+func (schema Schema) ValidateRequestValue(doc *Document, v interface{}) error {
+	return schema.validateValue(doc, v, requestContext)
+}
+
+// ValidateResponseValue validates the value sent in a response. In
+// addition to ValidateValue, the writeOnly properties must not be in
+// the value, and they are not required even if they are listed in
+// required.
+func (schema Schema) ValidateResponseValue(doc *Document, v interface{}) error {
+	return schema.validateValue(doc, v, responseContext)
+}
+
+func (schema Schema) validateValue(doc *Document, v interface{}, context valueContext) error {
+	value, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+	vv := newValueValidator(doc)
+	vv.context = context
+	vv.validate(&schema, value, "")
+	return vv.v.err()
+}
+
+// normalizeValue converts the value into the types decoded from JSON.
+// The values of the other types are encoded into JSON and decoded.
+func normalizeValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, string, json.Number, float64, float32,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v, nil
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i := range v {
+			value, err := normalizeValue(v[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for k := range v {
+			value, err := normalizeValue(v[k])
+			if err != nil {
+				return nil, err
+			}
+			values[k] = value
+		}
+		return values, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// valueContext is where the value is sent, which decides whether
+// readOnly and writeOnly are checked.
+type valueContext int
+
+const (
+	anyContext valueContext = iota
+	requestContext
+	responseContext
+)
+
+// valueValidator validates values against schemas, like the ones
+// decoded from JSON. The references in the schemas are resolved in
+// doc. The errors are reported with the JSON pointers to the invalid
+// values.
+type valueValidator struct {
+	doc     *Document
+	v       *validator
+	context valueContext
+}
+
+func newValueValidator(doc *Document) *valueValidator {
+	return &valueValidator{doc: doc, v: &validator{}}
+}
+
+// valid reports whether the value is valid against the schema,
+// without reporting the errors.
+func (vv *valueValidator) valid(schema *Schema, value interface{}) bool {
+	sub := &valueValidator{doc: vv.doc, v: &validator{}, context: vv.context}
+	sub.validate(schema, value, "")
+	return len(sub.v.errs) == 0
+}
+
+func (vv *valueValidator) report(ptr, keyword, format string, args ...interface{}) {
+	vv.v.report(ptr, ErrValue{Keyword: keyword, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (vv *valueValidator) validate(schema *Schema, value interface{}, ptr string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		resolved, err := resolveChain(vv.doc, schema.Ref, schema)
+		if err != nil {
+			vv.v.report(ptr, ErrUnresolvedRef{Ref: schema.Ref, Err: err})
+			return
+		}
+		if !vv.doc.IsOAS31() || !hasSiblings(schema) {
+			schema = resolved.(*Schema)
+		} else {
+			// OAS 3.1 applies both the referenced schema and the
+			// keywords beside $ref
+			vv.validate(resolved.(*Schema), value, ptr)
+			siblings := *schema
+			siblings.Ref = ""
+			schema = &siblings
+		}
+	}
+	if schema.Bool != nil {
+		if !*schema.Bool {
+			vv.report(ptr, "false", "no value is allowed")
+		}
+		return
+	}
+	if !vv.validateType(schema, value, ptr) {
+		return
+	}
+	if err := vv.doc.formatRegistry().Check(schema.Format, value); err != nil {
+		vv.report(ptr, "format", "%s: %s", schema.Format, err)
+	}
+	if len(schema.Enum) > 0 && !matchEnum(schema.Enum, value) {
+		vv.report(ptr, "enum", "%v is not one of %v", value, schema.Enum)
+	}
+	if schema.Const != nil && !equalValue(schema.Const, value) {
+		vv.report(ptr, "const", "%v is not %v", value, schema.Const)
+	}
+	switch value := value.(type) {
+	case string:
+		vv.validateString(schema, value, ptr)
+	case []interface{}:
+		vv.validateArray(schema, value, ptr)
+	case map[string]interface{}:
+		vv.validateObject(schema, value, ptr)
+	default:
+		if f, ok := toFloat(value); ok {
+			vv.validateNumber(schema, f, ptr)
+		}
+	}
+	vv.validateComposition(schema, value, ptr)
+}
+
+// validateType reports whether the value matches the type of the
+// schema. If not, the other keywords are not checked.
+func (vv *valueValidator) validateType(schema *Schema, value interface{}, ptr string) bool {
+	types := schema.TypeNames()
+	if value == nil {
+		if schema.Nullable || len(types) == 0 || containsString(types, "null") {
+			return false // nothing to check further
+		}
+		vv.report(ptr, "type", "null is not allowed")
+		return false
+	}
+	if len(types) == 0 {
+		return true
+	}
+	actual := typeOfValue(value)
+	for _, typ := range types {
+		if typ == actual || (typ == "number" && actual == "integer") {
+			return true
+		}
+	}
+	vv.report(ptr, "type", "%s is not %s", actual, joinTypes(types))
+	return false
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	return fmt.Sprintf("one of %v", types)
+}
+
+// typeOfValue returns the JSON Schema type name of the value.
+func typeOfValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if f, ok := toFloat(value); ok {
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts the numeric value into float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
+
+func matchEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if equalValue(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValue reports whether the values are equal as JSON values.
+func equalValue(a, b interface{}) bool {
+	fa, aok := toFloat(a)
+	fb, bok := toFloat(b)
+	if aok || bok {
+		return aok && bok && fa == fb
+	}
+	switch a := a.(type) {
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalValue(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k := range a {
+			if _, ok := b[k]; !ok || !equalValue(a[k], b[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+var patternCache sync.Map // map[string]*regexp.Regexp
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+func (vv *valueValidator) validateString(schema *Schema, value string, ptr string) {
+	length := utf8.RuneCountInString(value)
+	if max := schema.MaxLength; max != nil && length > *max {
+		vv.report(ptr, "maxLength", "length %d is greater than %d", length, *max)
+	}
+	if min := schema.MinLength; min != nil && length < *min {
+		vv.report(ptr, "minLength", "length %d is less than %d", length, *min)
+	}
+	if schema.Pattern != "" {
+		re, err := compilePattern(schema.Pattern)
+		if err != nil {
+			vv.report(ptr, "pattern", "invalid pattern %q: %s", schema.Pattern, err)
+		} else if !re.MatchString(value) {
+			vv.report(ptr, "pattern", "%q does not match %q", value, schema.Pattern)
+		}
+	}
+}
+
+func (vv *valueValidator) validateNumber(schema *Schema, value float64, ptr string) {
+	if schema.Maximum != nil {
+		max := *schema.Maximum
+		if schema.ExclusiveMaximum && value >= max {
+			vv.report(ptr, "maximum", "%v is not less than %v", value, max)
+		} else if value > max {
+			vv.report(ptr, "maximum", "%v is greater than %v", value, max)
+		}
+	}
+	if schema.Minimum != nil {
+		min := *schema.Minimum
+		if schema.ExclusiveMinimum && value <= min {
+			vv.report(ptr, "minimum", "%v is not greater than %v", value, min)
+		} else if value < min {
+			vv.report(ptr, "minimum", "%v is less than %v", value, min)
+		}
+	}
+	if max := schema.ExclusiveMaximumValue; max != nil && value >= *max {
+		vv.report(ptr, "exclusiveMaximum", "%v is not less than %v", value, *max)
+	}
+	if min := schema.ExclusiveMinimumValue; min != nil && value <= *min {
+		vv.report(ptr, "exclusiveMinimum", "%v is not greater than %v", value, *min)
+	}
+	if m := schema.MultipleOf; m != nil && *m > 0 && !isMultipleOf(value, *m) {
+		vv.report(ptr, "multipleOf", "%v is not a multiple of %v", value, *m)
+	}
+}
+
+// isMultipleOf reports whether the value is a multiple of m, allowing
+// the rounding errors of the decimal fractions like 0.01.
+func isMultipleOf(value, m float64) bool {
+	q := value / m
+	return math.Abs(q-math.Round(q)) <= 1e-9*math.Max(1, math.Abs(q))
+}
+
+func (vv *valueValidator) validateArray(schema *Schema, value []interface{}, ptr string) {
+	if max := schema.MaxItems; max != nil && len(value) > *max {
+		vv.report(ptr, "maxItems", "%d items are more than %d", len(value), *max)
+	}
+	if min := schema.MinItems; min != nil && len(value) < *min {
+		vv.report(ptr, "minItems", "%d items are less than %d", len(value), *min)
+	}
+	if schema.UniqueItems {
+		for j := range value {
+			for i := 0; i < j; i++ {
+				if equalValue(value[i], value[j]) {
+					vv.report(joinPointer(ptr, strconv.Itoa(j)), "uniqueItems", "item is equal to item %d", i)
+					break
+				}
+			}
+		}
+	}
+	for i, item := range value {
+		itemPtr := joinPointer(ptr, strconv.Itoa(i))
+		if i < len(schema.PrefixItems) {
+			vv.validate(schema.PrefixItems[i], item, itemPtr)
+			continue
+		}
+		vv.validate(schema.Items, item, itemPtr)
+	}
+}
+
+func (vv *valueValidator) validateObject(schema *Schema, value map[string]interface{}, ptr string) {
+	if max := schema.MaxProperties; max != nil && len(value) > *max {
+		vv.report(ptr, "maxProperties", "%d properties are more than %d", len(value), *max)
+	}
+	if min := schema.MinProperties; min != nil && len(value) < *min {
+		vv.report(ptr, "minProperties", "%d properties are less than %d", len(value), *min)
+	}
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok && !vv.ignored(schema.Properties[name]) {
+			vv.report(ptr, "required", "%s is required", name)
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propPtr := joinPointer(ptr, name)
+		property, ok := schema.Properties[name]
+		if !ok {
+			if allowed := schema.AdditionalPropertiesAllowed; allowed != nil && !*allowed {
+				vv.report(propPtr, "additionalProperties", "%s is not allowed", name)
+				continue
+			}
+			vv.validate(schema.AdditionalProperties, value[name], propPtr)
+			continue
+		}
+		if vv.ignored(property) {
+			keyword := "readOnly"
+			if vv.context == responseContext {
+				keyword = "writeOnly"
+			}
+			vv.report(propPtr, keyword, "%s must not be sent in the %s", name, vv.context)
+			continue
+		}
+		vv.validate(property, value[name], propPtr)
+	}
+}
+
+// ignored reports whether the property is not sent in the context,
+// as it is readOnly in requests or writeOnly in responses.
+func (vv *valueValidator) ignored(property *Schema) bool {
+	if vv.context == anyContext {
+		return false
+	}
+	property = vv.doc.resolveSchema(property)
+	if property == nil {
+		return false
+	}
+	return (vv.context == requestContext && property.ReadOnly) ||
+		(vv.context == responseContext && property.WriteOnly)
+}
+
+func (context valueContext) String() string {
+	switch context {
+	case requestContext:
+		return "request"
+	case responseContext:
+		return "response"
+	}
+	return "value"
+}
+
+func (vv *valueValidator) validateComposition(schema *Schema, value interface{}, ptr string) {
+	for _, s := range schema.AllOf {
+		vv.validate(s, value, ptr)
+	}
+	if len(schema.AnyOf) > 0 && schema.Discriminator == nil {
+		matched := false
+		for _, s := range schema.AnyOf {
+			if vv.valid(s, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			vv.report(ptr, "anyOf", "value does not match any of the schemas")
+		}
+	}
+	if schema.Discriminator != nil && (len(schema.OneOf) > 0 || len(schema.AnyOf) > 0) {
+		vv.validateDiscriminator(schema, value, ptr)
+	} else if len(schema.OneOf) > 0 {
+		matched := 0
+		for _, s := range schema.OneOf {
+			if vv.valid(s, value) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			vv.report(ptr, "oneOf", "value matches %d schemas, not exactly one", matched)
+		}
+	}
+	if schema.Not != nil && vv.valid(schema.Not, value) {
+		vv.report(ptr, "not", "value matches the schema")
+	}
+}
+
+// validateDiscriminator validates the value against the branch of
+// oneOf or anyOf which the discriminator chooses. The value of the
+// discriminator property is mapped by the mapping, or regarded as the
+// name of the schema in the components.
+func (vv *valueValidator) validateDiscriminator(schema *Schema, value interface{}, ptr string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return // the type is checked by the branches
+	}
+	propertyName := schema.Discriminator.PropertyName
+	name, ok := obj[propertyName].(string)
+	if !ok {
+		vv.report(ptr, "discriminator", "%s is required as string", propertyName)
+		return
+	}
+	ref, ok := schema.Discriminator.Mapping[name]
+	if !ok {
+		ref = "#/components/schemas/" + name
+	} else if !strings.Contains(ref, "/") && !strings.Contains(ref, "#") {
+		ref = "#/components/schemas/" + ref
+	}
+	branches := schema.OneOf
+	if len(branches) == 0 {
+		branches = schema.AnyOf
+	}
+	for _, branch := range branches {
+		if branch != nil && branch.Ref == ref {
+			vv.validate(branch, value, ptr)
+			return
+		}
+	}
+	vv.report(joinPointer(ptr, propertyName), "discriminator", "%q does not choose any of the schemas", name)
+}
@@ -0,0 +1,276 @@
+package openapi_test
+
+import (
+	"reflect"
+	"testing"
+
+	openapi "github.com/nasa9084/go-openapi"
+)
+
+const valueSpec = `openapi: 3.0.3
+info:
+  title: value
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name, petType]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+          minLength: 1
+          maxLength: 10
+          pattern: '^[a-z]+$'
+        petType:
+          type: string
+        password:
+          type: string
+          writeOnly: true
+        tags:
+          type: array
+          maxItems: 2
+          items:
+            $ref: '#/components/schemas/Tag'
+        weight:
+          type: number
+          minimum: 1
+          maximum: 100
+          multipleOf: 2
+        nickname:
+          type: string
+          nullable: true
+    Tag:
+      type: string
+      enum: [cute, big]
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          properties:
+            indoor:
+              type: boolean
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          required: [bark]
+          properties:
+            bark:
+              type: boolean
+    AnyPet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          doggie: '#/components/schemas/Dog'
+    Labels:
+      type: object
+      additionalProperties:
+        type: string
+    Shape:
+      oneOf:
+        - type: object
+          required: [radius]
+          properties:
+            radius:
+              type: number
+        - type: object
+          required: [width]
+          properties:
+            width:
+              type: number
+      not:
+        required: [both]
+`
+
+func validationPointers(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	return errorPointers(t, err)
+}
+
+func TestSchema_ValidateValue(t *testing.T) {
+	doc, err := openapi.Load([]byte(valueSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates := []struct {
+		label    string
+		schema   string
+		value    interface{}
+		expected []string
+	}{
+		{
+			label:  "valid",
+			schema: "Pet",
+			value: map[string]interface{}{
+				"id": 1, "name": "kitty", "petType": "Cat",
+				"tags": []interface{}{"cute"}, "weight": 4.0, "nickname": nil,
+			},
+		},
+		{
+			label:  "all violations",
+			schema: "Pet",
+			value: map[string]interface{}{
+				"name": "Kitty-the-cat", "petType": 1,
+				"tags": []interface{}{"cute", "small", "big"}, "weight": 101.0,
+			},
+			expected: []string{"", "/name", "/name", "/petType", "/tags", "/tags/1", "/weight", "/weight"},
+		},
+		{
+			label:    "additionalProperties",
+			schema:   "Labels",
+			value:    map[string]interface{}{"color": "white", "size": 1},
+			expected: []string{"/size"},
+		},
+		{
+			label:    "null",
+			schema:   "Pet",
+			value:    nil,
+			expected: []string{""},
+		},
+		{
+			label:    "allOf",
+			schema:   "Dog",
+			value:    map[string]interface{}{"id": 1, "name": "pochi", "petType": "Dog"},
+			expected: []string{""},
+		},
+		{
+			label:  "discriminator",
+			schema: "AnyPet",
+			value:  map[string]interface{}{"id": 1, "name": "pochi", "petType": "doggie", "bark": true},
+		},
+		{
+			label:    "discriminator chooses the branch",
+			schema:   "AnyPet",
+			value:    map[string]interface{}{"id": 1, "name": "pochi", "petType": "doggie", "indoor": true},
+			expected: []string{""},
+		},
+		{
+			label:  "discriminator by schema name",
+			schema: "AnyPet",
+			value:  map[string]interface{}{"id": 1, "name": "tama", "petType": "Cat"},
+		},
+		{
+			label:    "unknown discriminator",
+			schema:   "AnyPet",
+			value:    map[string]interface{}{"id": 1, "name": "tama", "petType": "Bird"},
+			expected: []string{"/petType"},
+		},
+		{
+			label:  "oneOf",
+			schema: "Shape",
+			value:  map[string]interface{}{"radius": 1},
+		},
+		{
+			label:    "oneOf matches both",
+			schema:   "Shape",
+			value:    map[string]interface{}{"radius": 1, "width": 2},
+			expected: []string{""},
+		},
+		{
+			label:    "not",
+			schema:   "Shape",
+			value:    map[string]interface{}{"radius": 1, "both": true},
+			expected: []string{""},
+		},
+	}
+	for _, c := range candidates {
+		t.Run(c.label, func(t *testing.T) {
+			schema := doc.Components.Schemas[c.schema]
+			err := schema.ValidateValue(doc, c.value)
+			if got := validationPointers(t, err); !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("%v != %v: %v", got, c.expected, err)
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateValue_GoValue(t *testing.T) {
+	doc, err := openapi.Load([]byte(valueSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type pet struct {
+		ID      int      `json:"id"`
+		Name    string   `json:"name"`
+		PetType string   `json:"petType"`
+		Tags    []string `json:"tags,omitempty"`
+	}
+	schema := openapi.Schema{Ref: "#/components/schemas/Pet"}
+	if err := schema.ValidateValue(doc, pet{ID: 1, Name: "kitty", PetType: "Cat"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = schema.ValidateValue(doc, &pet{ID: 1, Name: "kitty", PetType: "Cat", Tags: []string{"tiny"}})
+	if got := validationPointers(t, err); !reflect.DeepEqual(got, []string{"/tags/0"}) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchema_ValidateRequestValue(t *testing.T) {
+	doc, err := openapi.Load([]byte(valueSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.Components.Schemas["Pet"]
+	// id is readOnly, so it is not required in requests
+	if err := schema.ValidateRequestValue(doc, map[string]interface{}{"name": "kitty", "petType": "Cat", "password": "secret"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = schema.ValidateRequestValue(doc, map[string]interface{}{"id": 1, "name": "kitty", "petType": "Cat"})
+	if got := validationPointers(t, err); !reflect.DeepEqual(got, []string{"/id"}) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchema_ValidateResponseValue(t *testing.T) {
+	doc, err := openapi.Load([]byte(valueSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.Components.Schemas["Pet"]
+	if err := schema.ValidateResponseValue(doc, map[string]interface{}{"id": 1, "name": "kitty", "petType": "Cat"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = schema.ValidateResponseValue(doc, map[string]interface{}{"name": "kitty", "petType": "Cat", "password": "secret"})
+	if got := validationPointers(t, err); !reflect.DeepEqual(got, []string{"", "/password"}) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchema_ValidateValue_RefSiblings(t *testing.T) {
+	doc, err := openapi.Load([]byte(`openapi: 3.1.0
+info:
+  title: siblings
+  version: 1.0.0
+components:
+  schemas:
+    S:
+      type: string
+      minLength: 1
+    B:
+      $ref: '#/components/schemas/S'
+      maxLength: 2
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.Components.Schemas["B"]
+	if err := schema.ValidateValue(doc, "ab"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, value := range []interface{}{"abcdef", "", 1} {
+		if err := schema.ValidateValue(doc, value); err == nil {
+			t.Errorf("%#v should be invalid", value)
+		}
+	}
+}
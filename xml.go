@@ -9,9 +9,24 @@ type XML struct {
 	Prefix    string
 	Attribute bool
 	Wrapped   bool
+
+	Extension map[string]interface{} `yaml:",inline"`
 }
 
 // Validate the values of XML object.
 func (xml XML) Validate() error {
-	return mustURL("xml.namespace", xml.Namespace)
+	return validateFirst(xml)
+}
+
+// GetExtension stores the value of the specification extension name
+// into the value pointed by v.
+func (xml XML) GetExtension(name string, v interface{}) error {
+	return getExtension(xml.Extension, name, v)
+}
+
+func (xml XML) validate(v *validator, ptr string) {
+	validateExtension(v, ptr, xml.Extension)
+	if err := mustURL("xml.namespace", xml.Namespace); err != nil {
+		v.report(joinPointer(ptr, "namespace"), err)
+	}
 }